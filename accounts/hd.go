@@ -40,6 +40,12 @@ var DefaultBaseDerivationPath = DerivationPath{0x80000000 + 44, 0x80000000 + 60,
 // second at m/44'/60'/0'/1, etc.
 var LegacyLedgerBaseDerivationPath = DerivationPath{0x80000000 + 44, 0x80000000 + 60, 0x80000000 + 0, 0}
 
+// HarmonyBaseDerivationPath is the base path of Harmony accounts, using the
+// SLIP-44 coin type 1023. The first account will be at m/44'/1023'/0'/0/0,
+// the second at m/44'/1023'/0'/0/1, etc. An address is the same on every
+// shard, so one path serves an account on all shards.
+var HarmonyBaseDerivationPath = DerivationPath{0x80000000 + 44, 0x80000000 + 1023, 0x80000000 + 0, 0, 0}
+
 // DerivationPath represents the computer friendly version of a hierarchical
 // deterministic wallet account derivaion path.
 //
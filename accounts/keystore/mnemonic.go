@@ -0,0 +1,98 @@
+package keystore
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"errors"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/harmony-one/harmony/accounts"
+	bip39 "github.com/tyler-smith/go-bip39"
+)
+
+// mnemonicEntropyBits yields a 12-word mnemonic
+const mnemonicEntropyBits = 128
+
+// ErrInvalidMnemonic is returned for a mnemonic that is not a valid BIP-39
+// phrase.
+var ErrInvalidMnemonic = errors.New("invalid mnemonic")
+
+// errUnusableKey is returned in the extremely unlikely case (< 1 in 2^127)
+// that a key of the derivation path is not a valid secp256k1 key.
+var errUnusableKey = errors.New("unusable derived key")
+
+// NewMnemonic generates a new random BIP-39 mnemonic.
+func NewMnemonic() (string, error) {
+	entropy, err := bip39.NewEntropy(mnemonicEntropyBits)
+	if err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// DeriveKey derives the BIP-32 private key at path from the seed of a BIP-39
+// mnemonic.
+func DeriveKey(mnemonic string, path accounts.DerivationPath) (*ecdsa.PrivateKey, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, "")
+	if err != nil {
+		return nil, ErrInvalidMnemonic
+	}
+	return deriveKeyFromSeed(seed, path)
+}
+
+// deriveKeyFromSeed derives the BIP-32 private key at path from the seed.
+// Unlike hdkeychain, it keeps the leading zero bytes of the intermediate keys,
+// without which some seeds yield keys other wallets do not derive.
+func deriveKeyFromSeed(seed []byte, path accounts.DerivationPath) (*ecdsa.PrivateKey, error) {
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	key, chainCode := sum[:32], sum[32:]
+	if k := new(big.Int).SetBytes(key); k.Sign() == 0 || k.Cmp(btcec.S256().N) >= 0 {
+		return nil, errUnusableKey
+	}
+	for _, n := range path {
+		data := make([]byte, 0, 37)
+		if n >= hdkeychain.HardenedKeyStart {
+			data = append(append(data, 0), key...)
+		} else {
+			_, pub := btcec.PrivKeyFromBytes(btcec.S256(), key)
+			data = append(data, pub.SerializeCompressed()...)
+		}
+		data = append(data, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+
+		mac := hmac.New(sha512.New, chainCode)
+		mac.Write(data)
+		sum := mac.Sum(nil)
+		il := new(big.Int).SetBytes(sum[:32])
+		if il.Cmp(btcec.S256().N) >= 0 {
+			return nil, errUnusableKey
+		}
+		child := il.Add(il, new(big.Int).SetBytes(key))
+		child.Mod(child, btcec.S256().N)
+		if child.Sign() == 0 {
+			return nil, errUnusableKey
+		}
+		key, chainCode = math.PaddedBigBytes(child, 32), sum[32:]
+	}
+	return crypto.ToECDSA(key)
+}
+
+// ImportMnemonic stores the index-th Harmony account of a BIP-39 mnemonic
+// into the key directory, encrypted with the passphrase.
+func (ks *KeyStore) ImportMnemonic(mnemonic string, index uint32, passphrase string) (accounts.Account, error) {
+	path := make(accounts.DerivationPath, len(accounts.HarmonyBaseDerivationPath))
+	copy(path, accounts.HarmonyBaseDerivationPath)
+	path[len(path)-1] = index
+
+	privateKey, err := DeriveKey(mnemonic, path)
+	if err != nil {
+		return accounts.Account{}, err
+	}
+	return ks.ImportECDSA(privateKey, passphrase)
+}
@@ -0,0 +1,102 @@
+package keystore
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/harmony-one/harmony/accounts"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestDeriveKey(t *testing.T) {
+	// BIP-44 test vector shared by common Ethereum wallets
+	key, err := DeriveKey(testMnemonic, accounts.DefaultBaseDerivationPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := common.HexToAddress("0x9858EfFD232B4033E47d90003D41EC34EcaEda94")
+	if have := crypto.PubkeyToAddress(key.PublicKey); have != want {
+		t.Errorf("derived address mismatch: have %x, want %x", have, want)
+	}
+
+	if _, err := DeriveKey("abandon abandon", accounts.HarmonyBaseDerivationPath); err != ErrInvalidMnemonic {
+		t.Errorf("expected ErrInvalidMnemonic, got %v", err)
+	}
+}
+
+func TestDeriveKeyLeadingZeros(t *testing.T) {
+	// BIP-32 test vector 4, whose key at m/0H has a leading zero byte
+	seed, _ := hex.DecodeString("3ddd5602285899a946114506157c7997e5444528f3003f6134712147db19b678")
+	for _, vector := range []struct {
+		path accounts.DerivationPath
+		xprv string
+	}{
+		{accounts.DerivationPath{}, "xprv9s21ZrQH143K48vGoLGRPxgo2JNkJ3J3fqkirQC2zVdk5Dgd5w14S7fRDyHH4dWNHUgkvsvNDCkvAwcSHNAQwhwgNMgZhLtQC63zxwhQmRv"},
+		{accounts.DerivationPath{0x80000000}, "xprv9vB7xEWwNp9kh1wQRfCCQMnZUEG21LpbR9NPCNN1dwhiZkjjeGRnaALmPXCX7SgjFTiCTT6bXes17boXtjq3xLpcDjzEuGLQBM5ohqkao9G"},
+		{accounts.DerivationPath{0x80000000, 0x80000001}, "xprv9xJocDuwtYCMNAo3Zw76WENQeAS6WGXQ55RCy7tDJ8oALr4FWkuVoHJeHVAcAqiZLE7Je3vZJHxspZdFHfnBEjHqU5hG1Jaj32dVoS6XLT1"},
+	} {
+		extendedKey, err := hdkeychain.NewKeyFromString(vector.xprv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err := extendedKey.ECPrivKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		key, err := deriveKeyFromSeed(seed, vector.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have := crypto.FromECDSA(key); !bytes.Equal(have, want.Serialize()) {
+			t.Errorf("%v: derived key mismatch: have %x, want %x", vector.path, have, want.Serialize())
+		}
+	}
+}
+
+func TestNewMnemonic(t *testing.T) {
+	mnemonic, err := NewMnemonic()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if words := strings.Fields(mnemonic); len(words) != 12 {
+		t.Errorf("expected 12 words, got %d", len(words))
+	}
+	if _, err := DeriveKey(mnemonic, accounts.HarmonyBaseDerivationPath); err != nil {
+		t.Errorf("cannot derive key from new mnemonic: %v", err)
+	}
+}
+
+func TestImportMnemonic(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keystore-mnemonic")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ks := NewKeyStore(dir, veryLightScryptN, veryLightScryptP)
+
+	first, err := ks.ImportMnemonic(testMnemonic, 0, "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := ks.ImportMnemonic(testMnemonic, 1, "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Address == second.Address {
+		t.Error("accounts at different indices share an address")
+	}
+	if _, err := ks.ImportMnemonic(testMnemonic, 0, "foo"); err == nil {
+		t.Error("expected error re-importing the same account")
+	}
+	if err := ks.Unlock(first, "foo"); err != nil {
+		t.Errorf("cannot unlock imported account: %v", err)
+	}
+}
@@ -9,6 +9,8 @@ import (
 type Client struct {
 	ShardID      uint32               // ShardID
 	UpdateBlocks func([]*types.Block) // Closure function used to sync new block with the leader. Once the leader finishes the consensus on a new block, it will send it to the clients. Clients use this method to update their blockchain
+	// UpdateBlocksWithSig is like UpdateBlocks, but called with blocks sent along with their commit signatures
+	UpdateBlocksWithSig func([]*types.Block)
 
 	// The p2p host used to send/receive p2p messages
 	host p2p.Host
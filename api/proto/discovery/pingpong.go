@@ -3,7 +3,11 @@ Package proto/discovery implements the discovery ping protocol among nodes.
 
 pingpong.go adds support of ping messages.
 
-ping: from node to peers, sending IP/Port/PubKey info
+ping: from node to peers, sending IP/Port/PubKey info and the role the node
+      wants to join the shard with
+ack:  from the shard leader to a pinging node, acknowledging the role the
+      node has been admitted with
+leave: from node to peers on clean shutdown, carrying the same info as ping
 */
 
 package discovery
@@ -32,7 +36,7 @@ func (p PingMessageType) String() string {
 }
 
 // NewPingMessage creates a new Ping message based on the p2p.Peer input
-func NewPingMessage(peer p2p.Peer, isClient bool) *PingMessageType {
+func NewPingMessage(peer p2p.Peer, role node.RoleType) *PingMessageType {
 	ping := new(PingMessageType)
 
 	ping.Version = proto.ProtocolVersion
@@ -40,12 +44,11 @@ func NewPingMessage(peer p2p.Peer, isClient bool) *PingMessageType {
 	ping.Node.IP = peer.IP
 	ping.Node.Port = peer.Port
 	ping.Node.PeerID = peer.PeerID
-	if !isClient {
+	ping.Node.Role = role
+	if role == node.ValidatorRole {
 		ping.Node.PubKey = peer.ConsensusPubKey.Serialize()
-		ping.Node.Role = node.ValidatorRole
 	} else {
 		ping.Node.PubKey = nil
-		ping.Node.Role = node.ClientRole
 	}
 
 	return ping
@@ -80,3 +83,72 @@ func (p PingMessageType) ConstructPingMessage() []byte {
 	}
 	return byteBuffer.Bytes()
 }
+
+// ConstructLeaveMessage contructs the message a node sends to its peers
+// when it shuts down cleanly
+func (p PingMessageType) ConstructLeaveMessage() []byte {
+	byteBuffer := bytes.NewBuffer([]byte{byte(proto.Node)})
+	byteBuffer.WriteByte(byte(node.Leave))
+
+	encoder := gob.NewEncoder(byteBuffer)
+	err := encoder.Encode(p)
+	if err != nil {
+		utils.Logger().Error().Err(err).Msg("[ConstructLeaveMessage] Encode")
+		return nil
+	}
+	return byteBuffer.Bytes()
+}
+
+// JoinAckMessageType defines the data structure of the join acknowledgement
+// the shard leader sends back to a pinging node
+type JoinAckMessageType struct {
+	Version uint16 // version of the protocol
+	ShardID uint32 // shard the node has joined
+	Node    node.Info
+}
+
+func (a JoinAckMessageType) String() string {
+	return fmt.Sprintf("ack:%v/%v=>%v:%v@%v", a.Node.Role, a.Version, a.Node.IP, a.Node.Port, a.ShardID)
+}
+
+// NewJoinAckMessage creates a new join acknowledgement for the node described
+// by the given ping, admitting it into shardID with the role it declared
+func NewJoinAckMessage(shardID uint32, ping *PingMessageType) *JoinAckMessageType {
+	ack := new(JoinAckMessageType)
+
+	ack.Version = proto.ProtocolVersion
+	ack.ShardID = shardID
+	ack.Node = ping.Node
+
+	return ack
+}
+
+// GetJoinAckMessage deserializes the join acknowledgement from a list of byte
+func GetJoinAckMessage(payload []byte) (*JoinAckMessageType, error) {
+	ack := new(JoinAckMessageType)
+
+	r := bytes.NewBuffer(payload)
+	decoder := gob.NewDecoder(r)
+	err := decoder.Decode(ack)
+
+	if err != nil {
+		utils.Logger().Error().Err(err).Msg("[GetJoinAckMessage] Decode")
+		return nil, fmt.Errorf("Decode JoinAck Error")
+	}
+
+	return ack, nil
+}
+
+// ConstructJoinAckMessage contructs join acknowledgement from leader to node
+func (a JoinAckMessageType) ConstructJoinAckMessage() []byte {
+	byteBuffer := bytes.NewBuffer([]byte{byte(proto.Node)})
+	byteBuffer.WriteByte(byte(node.JoinAck))
+
+	encoder := gob.NewEncoder(byteBuffer)
+	err := encoder.Encode(a)
+	if err != nil {
+		utils.Logger().Error().Err(err).Msg("[ConstructJoinAckMessage] Encode")
+		return nil
+	}
+	return byteBuffer.Bytes()
+}
@@ -46,7 +46,7 @@ var (
 )
 
 func TestString(test *testing.T) {
-	ping1 := NewPingMessage(p1, false)
+	ping1 := NewPingMessage(p1, node.ValidatorRole)
 
 	r1 := fmt.Sprintf("%v", *ping1)
 	if strings.Compare(r1, e1) != 0 {
@@ -62,7 +62,7 @@ func TestString(test *testing.T) {
 }
 
 func TestSerialize(test *testing.T) {
-	ping1 := NewPingMessage(p1, true)
+	ping1 := NewPingMessage(p1, node.ClientRole)
 	buf1 = ping1.ConstructPingMessage()
 	msg1, err := proto.GetMessagePayload(buf1)
 	if err != nil {
@@ -76,3 +76,47 @@ func TestSerialize(test *testing.T) {
 		test.Error("Serialize/Deserialze Ping Message Failed")
 	}
 }
+
+func TestJoinAckSerialize(test *testing.T) {
+	ping1 := NewPingMessage(p1, node.ExplorerRole)
+	ack1 := NewJoinAckMessage(1, ping1)
+	buf := ack1.ConstructJoinAckMessage()
+	msgType, err := proto.GetMessageType(buf)
+	if err != nil || node.MessageType(msgType) != node.JoinAck {
+		test.Errorf("expect message type %v, got %v (err %v)", node.JoinAck, msgType, err)
+	}
+	msg, err := proto.GetMessagePayload(buf)
+	if err != nil {
+		test.Error("GetMessagePayload Failed!")
+	}
+	ack, err := GetJoinAckMessage(msg)
+	if err != nil {
+		test.Error("JoinAck failed!")
+	}
+	if !reflect.DeepEqual(ack, ack1) {
+		test.Error("Serialize/Deserialze JoinAck Message Failed")
+	}
+	if ack.Node.Role != node.ExplorerRole || ack.Node.PubKey != nil {
+		test.Errorf("unexpected acknowledged node %v", ack.Node)
+	}
+}
+
+func TestLeaveSerialize(test *testing.T) {
+	ping1 := NewPingMessage(p1, node.ValidatorRole)
+	buf := ping1.ConstructLeaveMessage()
+	msgType, err := proto.GetMessageType(buf)
+	if err != nil || node.MessageType(msgType) != node.Leave {
+		test.Errorf("expect message type %v, got %v (err %v)", node.Leave, msgType, err)
+	}
+	msg, err := proto.GetMessagePayload(buf)
+	if err != nil {
+		test.Error("GetMessagePayload Failed!")
+	}
+	leave, err := GetPingMessage(msg)
+	if err != nil {
+		test.Error("Leave failed!")
+	}
+	if !reflect.DeepEqual(leave, ping1) {
+		test.Error("Serialize/Deserialze Leave Message Failed")
+	}
+}
@@ -0,0 +1,157 @@
+package proto
+
+import (
+	"sync"
+
+	libp2p_peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+)
+
+// IncomingMessage is a message received from the network being dispatched
+type IncomingMessage struct {
+	Category MessageCategory
+	// Type is the message type byte, or 0 for the messages handled by
+	// category; their payload starts right after the category byte.
+	Type    byte
+	Payload []byte
+	Sender  libp2p_peer.ID
+}
+
+// MessageHandler processes an incoming message
+type MessageHandler func(msg *IncomingMessage)
+
+// Middleware wraps the handlers of all message types, e.g. to count, filter
+// or throttle the messages before they reach the handler.
+type Middleware func(next MessageHandler) MessageHandler
+
+// Errors returned by Dispatcher
+var (
+	ErrHandlerExists = errors.New("message handler already registered")
+	ErrNoHandler     = errors.New("no handler registered for message")
+)
+
+type messageKey struct {
+	category MessageCategory
+	msgType  byte
+}
+
+// Dispatcher routes the incoming messages to the handlers registered for
+// their category and type.
+type Dispatcher struct {
+	mutex            sync.RWMutex
+	typeHandlers     map[messageKey]MessageHandler
+	categoryHandlers map[MessageCategory]MessageHandler
+	middlewares      []Middleware
+	// the handlers wrapped in the middlewares, rebuilt on Register and Use
+	wrappedTypeHandlers     map[messageKey]MessageHandler
+	wrappedCategoryHandlers map[MessageCategory]MessageHandler
+}
+
+// NewDispatcher returns a dispatcher without any handler.
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{
+		typeHandlers:            make(map[messageKey]MessageHandler),
+		categoryHandlers:        make(map[MessageCategory]MessageHandler),
+		wrappedTypeHandlers:     make(map[messageKey]MessageHandler),
+		wrappedCategoryHandlers: make(map[MessageCategory]MessageHandler),
+	}
+}
+
+// wrap returns the handler wrapped in the middlewares.
+func (d *Dispatcher) wrap(handler MessageHandler) MessageHandler {
+	for i := len(d.middlewares) - 1; i >= 0; i-- {
+		handler = d.middlewares[i](handler)
+	}
+	return handler
+}
+
+// Register sets the handler of the messages of the category and type, whose
+// payload follows the category and type bytes.
+func (d *Dispatcher) Register(category MessageCategory, msgType byte, handler MessageHandler) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	key := messageKey{category, msgType}
+	if _, ok := d.typeHandlers[key]; ok {
+		return errors.Wrapf(ErrHandlerExists, "category %d type %d", category, msgType)
+	}
+	d.typeHandlers[key] = handler
+	d.wrappedTypeHandlers[key] = d.wrap(handler)
+	return nil
+}
+
+// RegisterCategory sets the handler of the messages of the category that
+// have no type byte, such as consensus messages; their payload follows the
+// category byte. Handlers registered for a category and type take
+// precedence.
+func (d *Dispatcher) RegisterCategory(category MessageCategory, handler MessageHandler) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if _, ok := d.categoryHandlers[category]; ok {
+		return errors.Wrapf(ErrHandlerExists, "category %d", category)
+	}
+	d.categoryHandlers[category] = handler
+	d.wrappedCategoryHandlers[category] = d.wrap(handler)
+	return nil
+}
+
+// Use adds a middleware around all the handlers. The middleware added first
+// sees the messages first.
+func (d *Dispatcher) Use(middleware Middleware) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.middlewares = append(d.middlewares, middleware)
+	for key, handler := range d.typeHandlers {
+		d.wrappedTypeHandlers[key] = d.wrap(handler)
+	}
+	for category, handler := range d.categoryHandlers {
+		d.wrappedCategoryHandlers[category] = d.wrap(handler)
+	}
+}
+
+// Dispatch parses the message content and passes it to its handler through
+// the middlewares.
+func (d *Dispatcher) Dispatch(content []byte, sender libp2p_peer.ID) error {
+	category, err := GetMessageCategory(content)
+	if err != nil {
+		return err
+	}
+	msg := &IncomingMessage{Category: category, Sender: sender}
+
+	d.mutex.RLock()
+	var handler MessageHandler
+	if len(content) >= MessageCategoryBytes+MessageTypeBytes {
+		msgType := content[MessageCategoryBytes+MessageTypeBytes-1]
+		if handler = d.wrappedTypeHandlers[messageKey{category, msgType}]; handler != nil {
+			msg.Type = msgType
+			msg.Payload = content[MessageCategoryBytes+MessageTypeBytes:]
+		}
+	}
+	if handler == nil {
+		if handler = d.wrappedCategoryHandlers[category]; handler != nil {
+			msg.Payload = content[MessageCategoryBytes:]
+		}
+	}
+	d.mutex.RUnlock()
+
+	if handler == nil {
+		if len(content) < MessageCategoryBytes+MessageTypeBytes {
+			return errors.Wrapf(ErrNoHandler, "category %d", category)
+		}
+		return errors.Wrapf(ErrNoHandler, "category %d type %d",
+			category, content[MessageCategoryBytes+MessageTypeBytes-1])
+	}
+	handler(msg)
+	return nil
+}
+
+// SenderFilter is a middleware that drops the messages whose sender is not
+// allowed.
+func SenderFilter(allow func(sender libp2p_peer.ID) bool) Middleware {
+	return func(next MessageHandler) MessageHandler {
+		return func(msg *IncomingMessage) {
+			if allow(msg.Sender) {
+				next(msg)
+			}
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package proto
+
+import (
+	"bytes"
+	"testing"
+
+	libp2p_peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+)
+
+func TestDispatcher(t *testing.T) {
+	d := NewDispatcher()
+	var got *IncomingMessage
+	record := func(msg *IncomingMessage) { got = msg }
+	if err := d.Register(Node, 2, record); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.RegisterCategory(Consensus, record); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Register(Node, 2, record); errors.Cause(err) != ErrHandlerExists {
+		t.Errorf("duplicate registration: got %v", err)
+	}
+
+	sender := libp2p_peer.ID("sender")
+	tests := []struct {
+		content  []byte
+		err      error
+		category MessageCategory
+		msgType  byte
+		payload  []byte
+	}{
+		{[]byte{byte(Node), 2, 7, 8}, nil, Node, 2, []byte{7, 8}},
+		{[]byte{byte(Consensus), 2, 7}, nil, Consensus, 0, []byte{2, 7}},
+		{[]byte{byte(Consensus)}, nil, Consensus, 0, []byte{}},
+		{[]byte{byte(Node), 3, 7}, ErrNoHandler, 0, 0, nil},
+		{[]byte{byte(DRand), 1}, ErrNoHandler, 0, 0, nil},
+	}
+	for i, test := range tests {
+		got = nil
+		err := d.Dispatch(test.content, sender)
+		if errors.Cause(err) != test.err {
+			t.Errorf("test %d: got error %v, expected %v", i, err, test.err)
+			continue
+		}
+		if test.err != nil {
+			if got != nil {
+				t.Errorf("test %d: handled", i)
+			}
+			continue
+		}
+		if got == nil || got.Category != test.category || got.Type != test.msgType ||
+			!bytes.Equal(got.Payload, test.payload) || got.Sender != sender {
+			t.Errorf("test %d: got %+v", i, got)
+		}
+	}
+	if err := d.Dispatch(nil, sender); err == nil {
+		t.Error("empty message dispatched")
+	}
+}
+
+func TestDispatcherMiddlewares(t *testing.T) {
+	d := NewDispatcher()
+	var calls []string
+	d.Use(func(next MessageHandler) MessageHandler {
+		return func(msg *IncomingMessage) {
+			calls = append(calls, "first")
+			next(msg)
+		}
+	})
+	d.Use(SenderFilter(func(sender libp2p_peer.ID) bool { return sender == "friend" }))
+	if err := d.RegisterCategory(Consensus, func(msg *IncomingMessage) {
+		calls = append(calls, "handler")
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := d.Dispatch([]byte{byte(Consensus)}, "friend"); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Dispatch([]byte{byte(Consensus)}, "stranger"); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"first", "handler", "first"}
+	if len(calls) != len(expected) {
+		t.Fatalf("got calls %v, expected %v", calls, expected)
+	}
+	for i := range calls {
+		if calls[i] != expected[i] {
+			t.Fatalf("got calls %v, expected %v", calls, expected)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package proto
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	libp2p_peer "github.com/libp2p/go-libp2p-core/peer"
+)
+
+// MessageCounter counts the dispatched messages per category and type, e.g.
+// to expose them as metrics.
+type MessageCounter struct {
+	mutex  sync.Mutex
+	counts map[messageKey]uint64
+}
+
+// NewMessageCounter returns a counter without any message counted.
+func NewMessageCounter() *MessageCounter {
+	return &MessageCounter{counts: make(map[messageKey]uint64)}
+}
+
+// Middleware returns the middleware counting the messages.
+func (c *MessageCounter) Middleware() Middleware {
+	return func(next MessageHandler) MessageHandler {
+		return func(msg *IncomingMessage) {
+			c.mutex.Lock()
+			c.counts[messageKey{msg.Category, msg.Type}]++
+			c.mutex.Unlock()
+			next(msg)
+		}
+	}
+}
+
+// Count returns the number of messages of the category and type counted;
+// the type is 0 for the messages handled by category.
+func (c *MessageCounter) Count(category MessageCategory, msgType byte) uint64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.counts[messageKey{category, msgType}]
+}
+
+// maxRateLimitedSenders is the number of senders tracked by a rate limit
+// above which the idle ones are forgotten.
+const maxRateLimitedSenders = 1024
+
+// RateLimit is a middleware that drops the messages of each sender beyond
+// rate messages per second, allowing bursts of up to burst messages.
+func RateLimit(rate float64, burst int) Middleware {
+	limiter := newRateLimiter(rate, burst)
+	return func(next MessageHandler) MessageHandler {
+		return func(msg *IncomingMessage) {
+			if limiter.allow(msg.Sender, time.Now()) {
+				next(msg)
+			}
+		}
+	}
+}
+
+// bucket holds the messages a sender may still send, as of last
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+type rateLimiter struct {
+	mutex   sync.Mutex
+	rate    float64
+	burst   float64
+	buckets map[libp2p_peer.ID]*bucket
+}
+
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	return &rateLimiter{
+		rate:    rate,
+		burst:   float64(burst),
+		buckets: make(map[libp2p_peer.ID]*bucket),
+	}
+}
+
+// tokens returns the messages the sender of the bucket may send at now.
+func (l *rateLimiter) tokens(b *bucket, now time.Time) float64 {
+	return math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+}
+
+// allow reports whether the sender may send a message at now, and if so
+// takes it from its bucket.
+func (l *rateLimiter) allow(sender libp2p_peer.ID, now time.Time) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	b, ok := l.buckets[sender]
+	if !ok {
+		if len(l.buckets) >= maxRateLimitedSenders {
+			l.prune(now)
+		}
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[sender] = b
+	}
+	b.tokens, b.last = l.tokens(b, now), now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// prune forgets the senders whose buckets are full again, which is the
+// state of a new bucket.
+func (l *rateLimiter) prune(now time.Time) {
+	for sender, b := range l.buckets {
+		if l.tokens(b, now) >= l.burst {
+			delete(l.buckets, sender)
+		}
+	}
+}
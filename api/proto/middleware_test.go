@@ -0,0 +1,71 @@
+package proto
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMessageCounter(t *testing.T) {
+	d := NewDispatcher()
+	counter := NewMessageCounter()
+	d.Use(counter.Middleware())
+	handler := func(*IncomingMessage) {}
+	if err := d.Register(Node, 2, handler); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.RegisterCategory(Consensus, handler); err != nil {
+		t.Fatal(err)
+	}
+	for _, content := range [][]byte{{byte(Node), 2}, {byte(Node), 2, 1}, {byte(Consensus), 2}, {byte(Node), 3}} {
+		d.Dispatch(content, "sender")
+	}
+	if count := counter.Count(Node, 2); count != 2 {
+		t.Errorf("counted %d node messages, expected 2", count)
+	}
+	if count := counter.Count(Consensus, 0); count != 1 {
+		t.Errorf("counted %d consensus messages, expected 1", count)
+	}
+	if count := counter.Count(Node, 3); count != 0 {
+		t.Errorf("counted %d unhandled messages", count)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	l := newRateLimiter(2, 3)
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		if !l.allow("a", now) {
+			t.Fatalf("message %d of the burst dropped", i)
+		}
+	}
+	if l.allow("a", now) {
+		t.Error("message beyond the burst allowed")
+	}
+	if !l.allow("b", now) {
+		t.Error("message of another sender dropped")
+	}
+	// one token every half second
+	if !l.allow("a", now.Add(500*time.Millisecond)) {
+		t.Error("message after refill dropped")
+	}
+	if l.allow("a", now.Add(600*time.Millisecond)) {
+		t.Error("message beyond the rate allowed")
+	}
+
+	l.prune(now.Add(time.Hour))
+	if len(l.buckets) != 0 {
+		t.Errorf("%d idle senders not pruned", len(l.buckets))
+	}
+
+	d := NewDispatcher()
+	d.Use(RateLimit(1, 1))
+	handled := 0
+	if err := d.RegisterCategory(Consensus, func(*IncomingMessage) { handled++ }); err != nil {
+		t.Fatal(err)
+	}
+	d.Dispatch([]byte{byte(Consensus)}, "sender")
+	d.Dispatch([]byte{byte(Consensus)}, "sender")
+	if handled != 1 {
+		t.Errorf("handled %d messages, expected 1", handled)
+	}
+}
@@ -30,6 +30,8 @@ const (
 	PING       // node send ip/pki to register with leader
 	ShardState // Deprecated
 	Staking
+	JoinAck // leader acknowledges the role a pinging node has joined with
+	Leave   // node announces it is shutting down
 )
 
 // BlockchainSyncMessage is a struct for blockchain sync message.
@@ -64,6 +66,7 @@ type RoleType int
 const (
 	ValidatorRole RoleType = iota
 	ClientRole
+	ExplorerRole
 )
 
 func (r RoleType) String() string {
@@ -72,6 +75,8 @@ func (r RoleType) String() string {
 		return "Validator"
 	case ClientRole:
 		return "Client"
+	case ExplorerRole:
+		return "Explorer"
 	}
 	return "Unknown"
 }
@@ -101,6 +106,7 @@ const (
 	CrossLink      // used for crosslink from beacon chain to shard chain
 	Receipt        // cross-shard transaction receipts
 	SlashCandidate // A report of a double-signing event
+	SyncWithSig    // like Sync, with the commit signatures of the blocks
 )
 
 var (
@@ -114,11 +120,13 @@ var (
 	syncB      = byte(Sync)
 	crossLinkB = byte(CrossLink)
 	receiptB   = byte(Receipt)
+	syncSigB   = byte(SyncWithSig)
 	// H suffix means header
 	slashH           = []byte{nodeB, blockB, slashB}
 	transactionListH = []byte{nodeB, txnB, sendB}
 	stakingTxnListH  = []byte{nodeB, stakingB, sendB}
 	syncH            = []byte{nodeB, blockB, syncB}
+	syncWithSigH     = []byte{nodeB, blockB, syncSigB}
 	crossLinkH       = []byte{nodeB, blockB, crossLinkB}
 	cxReceiptH       = []byte{nodeB, blockB, receiptB}
 )
@@ -171,6 +179,13 @@ func ConstructStakingTransactionListMessageAccount(
 	return byteBuffer.Bytes()
 }
 
+// blockWithSig is a block in a blocks sync message with signatures, along
+// with the commit signature and bitmap that finalized it.
+type blockWithSig struct {
+	Block              *types.Block
+	CommitSigAndBitmap []byte
+}
+
 // ConstructBlocksSyncMessage constructs blocks sync message to send blocks to other nodes
 func ConstructBlocksSyncMessage(blocks []*types.Block) []byte {
 	byteBuffer := bytes.NewBuffer(syncH)
@@ -179,6 +194,36 @@ func ConstructBlocksSyncMessage(blocks []*types.Block) []byte {
 	return byteBuffer.Bytes()
 }
 
+// ConstructBlocksSyncWithSigMessage constructs a blocks sync message that
+// also carries the commit signatures set on the blocks, so that clients can
+// verify them.
+func ConstructBlocksSyncWithSigMessage(blocks []*types.Block) []byte {
+	byteBuffer := bytes.NewBuffer(syncWithSigH)
+	blocksWithSig := make([]blockWithSig, len(blocks))
+	for i, block := range blocks {
+		blocksWithSig[i] = blockWithSig{block, block.GetCurrentCommitSig()}
+	}
+	blocksData, _ := rlp.EncodeToBytes(blocksWithSig)
+	byteBuffer.Write(blocksData)
+	return byteBuffer.Bytes()
+}
+
+// DeserializeBlocksSyncWithSigMessage decodes the blocks of a blocks sync
+// message with signatures, given the payload after the block message type.
+// The commit signatures sent along are set on the blocks.
+func DeserializeBlocksSyncWithSigMessage(d []byte) ([]*types.Block, error) {
+	var blocksWithSig []blockWithSig
+	if err := rlp.DecodeBytes(d, &blocksWithSig); err != nil {
+		return nil, err
+	}
+	blocks := make([]*types.Block, len(blocksWithSig))
+	for i, b := range blocksWithSig {
+		b.Block.SetCurrentCommitSig(b.CommitSigAndBitmap)
+		blocks[i] = b.Block
+	}
+	return blocks, nil
+}
+
 // ConstructSlashMessage ..
 func ConstructSlashMessage(witnesses slash.Records) []byte {
 	byteBuffer := bytes.NewBuffer(slashH)
@@ -1,6 +1,7 @@
 package node
 
 import (
+	"bytes"
 	"math/big"
 	"reflect"
 	"strings"
@@ -9,6 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
 
 	blockfactory "github.com/harmony-one/harmony/block/factory"
 	"github.com/harmony-one/harmony/core/state"
@@ -76,6 +78,7 @@ func TestConstructBlocksSyncMessage(t *testing.T) {
 	}
 
 	block1 := types.NewBlock(head, nil, nil, nil, nil, nil)
+	block1.SetCurrentCommitSig([]byte{1, 2, 3})
 
 	blocks := []*types.Block{
 		block1,
@@ -87,6 +90,28 @@ func TestConstructBlocksSyncMessage(t *testing.T) {
 		t.Error("Failed to contruct block sync message")
 	}
 
+	var legacy []*types.Block
+	if err := rlp.DecodeBytes(buf[len(syncH):], &legacy); err != nil {
+		t.Fatalf("cannot decode legacy blocks sync message: %s", err)
+	}
+	if len(legacy) != 1 || legacy[0].Hash() != block1.Hash() {
+		t.Fatal("legacy blocks mismatch")
+	}
+
+	buf = ConstructBlocksSyncWithSigMessage(blocks)
+	if !bytes.HasPrefix(buf, syncWithSigH) {
+		t.Fatal("wrong header of blocks sync message with signatures")
+	}
+	decoded, err := DeserializeBlocksSyncWithSigMessage(buf[len(syncWithSigH):])
+	if err != nil {
+		t.Fatalf("DeserializeBlocksSyncWithSigMessage() failed: %s", err)
+	}
+	if len(decoded) != 1 || decoded[0].Hash() != block1.Hash() {
+		t.Fatal("blocks mismatch")
+	}
+	if !bytes.Equal(decoded[0].GetCurrentCommitSig(), []byte{1, 2, 3}) {
+		t.Errorf("commit signature mismatch: %x", decoded[0].GetCurrentCommitSig())
+	}
 }
 
 func TestRoleTypeToString(t *testing.T) {
@@ -6,6 +6,7 @@ import (
 	"github.com/ethereum/go-ethereum/rpc"
 	proto_discovery "github.com/harmony-one/harmony/api/proto/discovery"
 	msg_pb "github.com/harmony-one/harmony/api/proto/message"
+	proto_node "github.com/harmony-one/harmony/api/proto/node"
 	"github.com/harmony-one/harmony/api/service"
 	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
 	"github.com/harmony-one/harmony/internal/utils"
@@ -76,11 +77,14 @@ func (s *Service) Run() {
 func (s *Service) contactP2pPeers() {
 
 	nodeConfig := nodeconfig.GetShardConfig(s.config.ShardID)
-	// Don't send ping message for Explorer Node
-	if nodeConfig.Role() == nodeconfig.ExplorerNode {
-		return
+	role := proto_node.ValidatorRole
+	switch {
+	case s.config.IsClient:
+		role = proto_node.ClientRole
+	case nodeConfig.Role() == nodeconfig.ExplorerNode:
+		role = proto_node.ExplorerRole
 	}
-	pingMsg := proto_discovery.NewPingMessage(s.host.GetSelfPeer(), s.config.IsClient)
+	pingMsg := proto_discovery.NewPingMessage(s.host.GetSelfPeer(), role)
 
 	msgBuf := host.ConstructP2pMessage(byte(0), pingMsg.ConstructPingMessage())
 	s.sentPingMessage(s.config.ShardGroupID, msgBuf)
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"net"
-	"sync"
 	"time"
 
 	manet "github.com/multiformats/go-multiaddr-net"
@@ -38,17 +37,50 @@ type Service struct {
 	discovery   *libp2pdis.RoutingDiscovery
 	messageChan chan *msg_pb.Message
 	started     bool
+	connectOpts ConnectOptions
+	initDone    chan struct{}
+	initErr     error
 }
 
-// ConnectionRetry set the number of retry of connection to bootnode in case the initial connection is failed
-var (
-	// retry for 30s and give up then
-	ConnectionRetry = 15
-)
+// ConnectOptions are the options of connecting to the bootnodes.
+type ConnectOptions struct {
+	Retries        int           // number of connection attempts per bootnode
+	AttemptTimeout time.Duration // bound of a single connection attempt
+	MaxWait        time.Duration // cap of the exponential backoff between attempts
+}
+
+// DefaultConnectOptions returns the default options of connecting to the
+// bootnodes: up to 15 attempts per bootnode, backing off exponentially.
+func DefaultConnectOptions() ConnectOptions {
+	return ConnectOptions{
+		Retries:        15,
+		AttemptTimeout: 10 * time.Second,
+		MaxWait:        30 * time.Second,
+	}
+}
+
+// Budget returns the longest time that connecting to a bootnode with the
+// options can take, i.e. all attempts timing out and the waits between them.
+func (opts ConnectOptions) Budget() time.Duration {
+	budget := time.Duration(opts.Retries) * opts.AttemptTimeout
+	backoff := p2p.NewExpBackoff(waitInRetry, opts.MaxWait, retryBackoffRatio)
+	for i := 1; i < opts.Retries; i++ {
+		budget += backoff.Cur
+		backoff.Backoff()
+		if backoff.Cur > backoff.Max {
+			backoff.Cur = backoff.Max
+		}
+	}
+	return budget
+}
+
+// ErrBootnodesUnreachable is returned when none of the bootnodes can be reached
+var ErrBootnodesUnreachable = errors.New("cannot connect to any bootnode")
 
 const (
 	waitInRetry       = 2 * time.Second
-	connectionTimeout = 3 * time.Minute
+	retryBackoffRatio = 1.5
+	connectionTimeout = 3 * time.Minute // of bootstrapping and advertising
 	findPeerInterval  = 60 * time.Second
 
 	// register to bootnode every ticker
@@ -68,6 +100,7 @@ func New(
 	if dataStorePath != "" {
 		dataStore, err := badger.NewDatastore(dataStorePath, nil)
 		if err != nil {
+			cancel()
 			return nil, errors.Wrapf(err,
 				"cannot open Badger datastore at %s", dataStorePath)
 		}
@@ -79,6 +112,7 @@ func New(
 
 	dht, err := libp2pdht.New(ctx, h.GetP2PHost(), dhtOpts...)
 	if err != nil {
+		cancel()
 		return nil, errors.Wrapf(err, "cannot create DHT")
 	}
 
@@ -93,75 +127,60 @@ func New(
 		bootnodes:   bootnodes,
 		discovery:   nil,
 		started:     false,
+		connectOpts: DefaultConnectOptions(),
+		initDone:    make(chan struct{}),
 	}, nil
 }
 
-// MustNew is a panic-on-error version of New.
-func MustNew(
-	h p2p.Host, rendezvous nodeconfig.GroupID, peerChan chan p2p.Peer,
-	bootnodes p2putils.AddrList, dataStorePath string,
-) *Service {
-	service, err := New(h, rendezvous, peerChan, bootnodes, dataStorePath)
-	if err != nil {
-		panic(err)
-	}
-	return service
+// SetConnectOptions sets the options of connecting to the bootnodes.
+// It must be called before the service is started.
+func (s *Service) SetConnectOptions(opts ConnectOptions) {
+	s.connectOpts = opts
 }
 
 // StartService starts network info service.
 func (s *Service) StartService() {
-	err := s.Init()
-	if err != nil {
-		utils.Logger().Error().Err(err).Msg("Service Init Failed")
+	s.initErr = s.Init()
+	close(s.initDone)
+	if s.initErr != nil {
+		utils.Logger().Error().Err(s.initErr).Msg("Service Init Failed")
 		return
 	}
 	s.Run()
 	s.started = true
 }
 
+// InitErr waits until the service is started and returns the error of its
+// initialization, e.g. ErrBootnodesUnreachable, so that the caller can fall
+// back to other means of joining the network.
+func (s *Service) InitErr() error {
+	<-s.initDone
+	return s.initErr
+}
+
 // Init initializes role conversion service.
 func (s *Service) Init() error {
-	ctx, cancel := context.WithTimeout(context.Background(), connectionTimeout)
-	defer cancel()
 	utils.Logger().Info().Msg("Init networkinfo service")
 
 	// Bootstrap the DHT. In the default configuration, this spawns a Background
 	// thread that will refresh the peer table every five minutes.
 	utils.Logger().Debug().Msg("Bootstrapping the DHT")
-	if err := s.dht.Bootstrap(ctx); err != nil {
+	bootstrapCtx, cancelBootstrap := context.WithTimeout(context.Background(), connectionTimeout)
+	defer cancelBootstrap()
+	if err := s.dht.Bootstrap(bootstrapCtx); err != nil {
 		return fmt.Errorf("error bootstrap dht: %s", err)
 	}
 
-	var wg sync.WaitGroup
-	if s.bootnodes == nil {
-		// TODO: should've passed in bootnodes through constructor.
-		s.bootnodes = p2putils.BootNodes
-	}
-
-	connected := false
-	for _, peerAddr := range s.bootnodes {
-		peerinfo, _ := peerstore.InfoFromP2pAddr(peerAddr)
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			for i := 0; i < ConnectionRetry; i++ {
-				if err := s.Host.GetP2PHost().Connect(ctx, *peerinfo); err != nil {
-					utils.Logger().Warn().Err(err).Int("try", i).Msg("can't connect to bootnode")
-					time.Sleep(waitInRetry)
-				} else {
-					utils.Logger().Info().Int("try", i).Interface("node", *peerinfo).Msg("connected to bootnode")
-					// it is okay if any bootnode is connected
-					connected = true
-					break
-				}
-			}
-		}()
+	// bootnodes are tried in parallel, so one's budget bounds them all
+	connectCtx, cancelConnect := context.WithTimeout(context.Background(), s.connectOpts.Budget())
+	err := s.connectBootnodes(connectCtx)
+	cancelConnect()
+	if err != nil {
+		return err
 	}
-	wg.Wait()
 
-	if !connected {
-		return fmt.Errorf("[FATAL] error connecting to bootnodes")
-	}
+	ctx, cancel := context.WithTimeout(context.Background(), connectionTimeout)
+	defer cancel()
 
 	// We use a rendezvous point "shardID" to announce our location.
 	utils.Logger().Info().Str("Rendezvous", string(s.Rendezvous)).Msg("Announcing ourselves...")
@@ -176,6 +195,86 @@ func (s *Service) Init() error {
 	return nil
 }
 
+// connectBootnodes contacts all bootnodes in parallel, retrying each one with
+// exponential backoff.  It returns as soon as any bootnode is connected,
+// cancelling the other attempts, and returns ErrBootnodesUnreachable if every
+// bootnode exhausted its retries, or the context error if it is cancelled.
+func (s *Service) connectBootnodes(ctx context.Context) error {
+	if s.bootnodes == nil {
+		// TODO: should've passed in bootnodes through constructor.
+		s.bootnodes = p2putils.BootNodes
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	results := make(chan bool, len(s.bootnodes))
+	tried := 0
+	for _, peerAddr := range s.bootnodes {
+		peerinfo, err := peerstore.InfoFromP2pAddr(peerAddr)
+		if err != nil {
+			utils.Logger().Warn().Err(err).
+				Str("addr", peerAddr.String()).
+				Msg("invalid bootnode address")
+			continue
+		}
+		tried++
+		go func() {
+			results <- s.connectBootnode(ctx, *peerinfo)
+		}()
+	}
+	for i := 0; i < tried; i++ {
+		if <-results {
+			// it is okay if any bootnode is connected
+			return nil
+		}
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return errors.Wrapf(ErrBootnodesUnreachable,
+		"tried %d bootnodes %d times each", tried, s.connectOpts.Retries)
+}
+
+// connectBootnode tries to connect to the bootnode up to the number of
+// retries in the connect options, backing off between the attempts, and
+// tells whether it succeeded.
+func (s *Service) connectBootnode(ctx context.Context, peerinfo peerstore.PeerInfo) bool {
+	opts := s.connectOpts
+	backoff := p2p.NewExpBackoff(waitInRetry, opts.MaxWait, retryBackoffRatio)
+	for i := 0; i < opts.Retries; i++ {
+		attemptCtx, cancel := context.WithTimeout(ctx, opts.AttemptTimeout)
+		err := s.Host.GetP2PHost().Connect(attemptCtx, peerinfo)
+		cancel()
+		if err == nil {
+			utils.Logger().Info().Int("try", i).Interface("node", peerinfo).Msg("connected to bootnode")
+			return true
+		}
+		if ctx.Err() != nil {
+			return false
+		}
+		if i == opts.Retries-1 {
+			utils.Logger().Warn().Err(err).Int("try", i).
+				Interface("node", peerinfo).
+				Msg("can't connect to bootnode, giving up")
+			break
+		}
+		utils.Logger().Warn().Err(err).Int("try", i).
+			Dur("nextRetryIn", backoff.Cur).
+			Msg("can't connect to bootnode")
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(backoff.Cur):
+		}
+		backoff.Backoff()
+		if backoff.Cur > backoff.Max {
+			backoff.Cur = backoff.Max
+		}
+	}
+	return false
+}
+
 // Run runs network info.
 func (s *Service) Run() {
 	defer close(s.stoppedChan)
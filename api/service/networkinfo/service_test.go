@@ -1,15 +1,19 @@
 package networkinfo
 
 import (
+	"context"
 	"testing"
 	"time"
 
+	"github.com/pkg/errors"
+
 	"github.com/harmony-one/harmony/crypto/bls"
 
 	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
 	"github.com/harmony-one/harmony/internal/utils"
 	"github.com/harmony-one/harmony/p2p"
 	"github.com/harmony-one/harmony/p2p/p2pimpl"
+	p2putils "github.com/harmony-one/harmony/p2p/utils"
 )
 
 func TestService(t *testing.T) {
@@ -40,3 +44,74 @@ func TestService(t *testing.T) {
 
 	s.StopService()
 }
+
+func TestConnectBootnodesCancel(t *testing.T) {
+	nodePriKey, _, err := utils.GenKeyP2P("127.0.0.1", "12346")
+	if err != nil {
+		t.Fatal(err)
+	}
+	selfPeer := p2p.Peer{IP: "127.0.0.1", Port: "12346", ConsensusPubKey: bls.RandPrivateKey().GetPublicKey()}
+	host, err := p2pimpl.NewHost(&selfPeer, nodePriKey)
+	if err != nil {
+		t.Fatal("unable to new host in harmony")
+	}
+	// nothing listens on the bootnode port
+	bootnodes, err := p2putils.StringsToAddrs([]string{
+		"/ip4/127.0.0.1/tcp/12347/p2p/QmVdSe4RdeC3xPemFuRG9Mhzo28EXbiBZh1tpjsuMbHqh1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := New(host, nodeconfig.GroupIDBeaconClient, nil, bootnodes, "")
+	if err != nil {
+		t.Fatalf("New() failed: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := s.connectBootnodes(ctx); err != context.DeadlineExceeded {
+		t.Errorf("got error %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("returned after %v", elapsed)
+	}
+}
+
+func TestConnectOptionsBudget(t *testing.T) {
+	opts := ConnectOptions{Retries: 3, AttemptTimeout: time.Second, MaxWait: 2500 * time.Millisecond}
+	// three attempts, then waits of 2s and 3s capped to 2.5s
+	if budget := opts.Budget(); budget != 7500*time.Millisecond {
+		t.Errorf("got budget %v", budget)
+	}
+}
+
+func TestInitErr(t *testing.T) {
+	nodePriKey, _, err := utils.GenKeyP2P("127.0.0.1", "12348")
+	if err != nil {
+		t.Fatal(err)
+	}
+	selfPeer := p2p.Peer{IP: "127.0.0.1", Port: "12348", ConsensusPubKey: bls.RandPrivateKey().GetPublicKey()}
+	host, err := p2pimpl.NewHost(&selfPeer, nodePriKey)
+	if err != nil {
+		t.Fatal("unable to new host in harmony")
+	}
+	// nothing listens on the bootnode port
+	bootnodes, err := p2putils.StringsToAddrs([]string{
+		"/ip4/127.0.0.1/tcp/12349/p2p/QmVdSe4RdeC3xPemFuRG9Mhzo28EXbiBZh1tpjsuMbHqh1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := New(host, nodeconfig.GroupIDBeaconClient, nil, bootnodes, "")
+	if err != nil {
+		t.Fatalf("New() failed: %s", err)
+	}
+	s.SetConnectOptions(ConnectOptions{Retries: 2, AttemptTimeout: time.Second, MaxWait: time.Second})
+
+	go s.StartService()
+	if err := s.InitErr(); errors.Cause(err) != ErrBootnodesUnreachable {
+		t.Errorf("got error %v", err)
+	}
+	s.StopService()
+}
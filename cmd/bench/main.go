@@ -0,0 +1,110 @@
+// bench measures the throughput of the network from the chain itself: the
+// number of transactions included per second and the time between blocks,
+// per shard, as recorded in the blocks served by the nodes' RPC.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/harmony-one/harmony/hmyclient"
+	"github.com/pkg/errors"
+)
+
+var (
+	version string
+	builtBy string
+	builtAt string
+	commit  string
+)
+
+const (
+	rpcTimeout = 10 * time.Second
+)
+
+func printVersion(me string) {
+	fmt.Fprintf(os.Stderr, "Harmony (C) 2019. %v, version %v-%v (%v %v)\n", path.Base(me), version, commit, builtBy, builtAt)
+	os.Exit(0)
+}
+
+func headNumber(client *hmyclient.Client) (uint64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+	number, err := client.BlockNumber(ctx)
+	return uint64(number), err
+}
+
+// fetchBlocks returns the summaries of the blocks from..to, inclusive.
+func fetchBlocks(client *hmyclient.Client, from, to uint64) ([]*hmyclient.BlockSummary, error) {
+	var blocks []*hmyclient.BlockSummary
+	for number := from; number <= to; number++ {
+		ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+		block, err := client.BlockSummaryByNumber(ctx, new(big.Int).SetUint64(number))
+		cancel()
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot get block %d", number)
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks, nil
+}
+
+func main() {
+	rpcURLs := flag.String("rpc", "http://localhost:9500", "comma-separated RPC endpoints, one per shard to measure")
+	numBlocks := flag.Uint64("blocks", 100, "number of latest blocks to measure")
+	follow := flag.Duration("follow", 0, "instead of the latest blocks, measure the blocks produced during this period from now")
+	versionFlag := flag.Bool("version", false, "Output version info")
+	flag.Parse()
+
+	if *versionFlag {
+		printVersion(os.Args[0])
+	}
+
+	urls := strings.Split(*rpcURLs, ",")
+	clients := make([]*hmyclient.Client, len(urls))
+	starts := make([]uint64, len(urls))
+	for i, url := range urls {
+		client, err := hmyclient.Dial(url)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "cannot connect to %s: %v\n", url, err)
+			os.Exit(1)
+		}
+		clients[i] = client
+		if starts[i], err = headNumber(client); err != nil {
+			fmt.Fprintf(os.Stderr, "cannot get the latest block of %s: %v\n", url, err)
+			os.Exit(1)
+		}
+	}
+	if *follow > 0 {
+		fmt.Printf("Following new blocks for %v...\n", *follow)
+		time.Sleep(*follow)
+	}
+
+	for i, client := range clients {
+		head, err := headNumber(client)
+		if err != nil {
+			fmt.Printf("%s: cannot get the latest block: %v\n", urls[i], err)
+			continue
+		}
+		from := starts[i]
+		if *follow == 0 {
+			from = 0
+			if head >= *numBlocks {
+				from = head - *numBlocks + 1
+			}
+		}
+		blocks, err := fetchBlocks(client, from, head)
+		if err != nil {
+			fmt.Printf("%s: %v\n", urls[i], err)
+			continue
+		}
+		fmt.Printf("%s (blocks %d-%d): %v\n", urls[i], from, head, computeStats(blocks))
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/harmony-one/harmony/hmyclient"
+)
+
+// shardStats are throughput figures computed from consecutive blocks of one
+// shard.
+type shardStats struct {
+	Blocks int
+	Txs    int
+	// time between the first and the last block
+	Span time.Duration
+	TPS  float64
+	// distribution of the time between two consecutive blocks
+	MinInterval    time.Duration
+	MedianInterval time.Duration
+	P90Interval    time.Duration
+	MaxInterval    time.Duration
+}
+
+// computeStats computes the stats of consecutive blocks sorted by number.
+// The transactions of the first block are not counted, since the time it
+// took to produce them lies before the measured span.
+func computeStats(blocks []*hmyclient.BlockSummary) shardStats {
+	stats := shardStats{Blocks: len(blocks)}
+	if len(blocks) < 2 {
+		return stats
+	}
+	intervals := make([]time.Duration, 0, len(blocks)-1)
+	for i := 1; i < len(blocks); i++ {
+		stats.Txs += blocks[i].TxCount
+		interval := time.Duration(blocks[i].Timestamp-blocks[i-1].Timestamp) * time.Second
+		intervals = append(intervals, interval)
+	}
+	stats.Span = time.Duration(blocks[len(blocks)-1].Timestamp-blocks[0].Timestamp) * time.Second
+	if stats.Span > 0 {
+		stats.TPS = float64(stats.Txs) / stats.Span.Seconds()
+	}
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i] < intervals[j] })
+	stats.MinInterval = intervals[0]
+	stats.MedianInterval = percentile(intervals, 50)
+	stats.P90Interval = percentile(intervals, 90)
+	stats.MaxInterval = intervals[len(intervals)-1]
+	return stats
+}
+
+// percentile returns the p-th percentile of sorted, non-empty durations
+// using the nearest-rank method.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func (s shardStats) String() string {
+	if s.Blocks < 2 {
+		return fmt.Sprintf("%d blocks, not enough to measure", s.Blocks)
+	}
+	return fmt.Sprintf(
+		"%d blocks, %d txs in %v: %.2f TPS; block interval min %v, median %v, p90 %v, max %v",
+		s.Blocks, s.Txs, s.Span, s.TPS,
+		s.MinInterval, s.MedianInterval, s.P90Interval, s.MaxInterval,
+	)
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/harmony-one/harmony/hmyclient"
+)
+
+func TestComputeStats(t *testing.T) {
+	var blocks []*hmyclient.BlockSummary
+	timestamp := uint64(1000)
+	for i, interval := range []uint64{0, 5, 8, 8, 9, 10, 8, 7, 8, 20, 8} {
+		timestamp += interval
+		blocks = append(blocks, &hmyclient.BlockSummary{
+			Number: uint64(i), Timestamp: timestamp, TxCount: 100,
+		})
+	}
+	stats := computeStats(blocks)
+	expected := shardStats{
+		Blocks:         11,
+		Txs:            1000,
+		Span:           91 * time.Second,
+		TPS:            1000.0 / 91,
+		MinInterval:    5 * time.Second,
+		MedianInterval: 8 * time.Second,
+		P90Interval:    10 * time.Second,
+		MaxInterval:    20 * time.Second,
+	}
+	if stats != expected {
+		t.Errorf("got %+v, expected %+v", stats, expected)
+	}
+
+	if stats := computeStats(blocks[:1]); stats != (shardStats{Blocks: 1}) {
+		t.Errorf("single block: got %+v", stats)
+	}
+}
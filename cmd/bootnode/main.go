@@ -6,11 +6,14 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"net/http"
+	_ "net/http/pprof"
 	"os"
 	"path"
 
 	"github.com/ethereum/go-ethereum/log"
 
+	viperconfig "github.com/harmony-one/harmony/internal/configs/viper"
 	"github.com/harmony-one/harmony/internal/utils"
 	"github.com/harmony-one/harmony/p2p"
 	"github.com/harmony-one/harmony/p2p/p2pimpl"
@@ -39,20 +42,46 @@ func main() {
 	logMaxSize := flag.Int("log_max_size", 100, "the max size in megabytes of the log file before it gets rotated")
 	keyFile := flag.String("key", "./.bnkey", "the private key file of the bootnode")
 	versionFlag := flag.Bool("version", false, "Output version info")
+	printConfig := flag.Bool("print_config", false, "Output the effective config, after applying the environment and config file, in the config file format")
 	verbosity := flag.Int("verbosity", 5, "Logging verbosity: 0=silent, 1=error, 2=warn, 3=info, 4=debug, 5=detail (default: 5)")
 	logConn := flag.Bool("log_conn", false, "log incoming/outgoing connections")
+	pprofPort := flag.Int("pprof_port", 0, "if not 0, serve the pprof profiles on this port of localhost")
 
 	flag.Parse()
 
 	if *versionFlag {
 		printVersion(os.Args[0])
 	}
+	// the bootnode section of the config file shared with the nodes, falling
+	// back to the settings of the nodes
+	configFileViper, err := viperconfig.CreateConfFileViper("./.hmy", "nodeconfig", "json")
+	if err != nil {
+		utils.FatalErrMsg(err, "cannot read config")
+	}
+	if err := viperconfig.ResetConfFlags(flag.CommandLine, viperconfig.CreateEnvViper(), configFileViper, "bootnode"); err != nil {
+		utils.FatalErrMsg(err, "cannot read config")
+	}
+	if *printConfig {
+		if err := viperconfig.PrintConfig(os.Stdout, flag.CommandLine, "bootnode"); err != nil {
+			utils.FatalErrMsg(err, "cannot print config")
+		}
+		os.Exit(0)
+	}
 
 	// Logging setup
 	utils.SetLogContext(*port, *ip)
 	utils.SetLogVerbosity(log.Lvl(*verbosity))
 	utils.AddLogFile(fmt.Sprintf("%v/bootnode-%v-%v.log", *logFolder, *ip, *port), *logMaxSize)
 
+	if *pprofPort != 0 {
+		addr := fmt.Sprintf("127.0.0.1:%d", *pprofPort)
+		go func() {
+			if err := http.ListenAndServe(addr, nil); err != nil {
+				utils.Logger().Error().Err(err).Str("addr", addr).Msg("pprof server failed")
+			}
+		}()
+	}
+
 	privKey, _, err := utils.LoadKeyFromFile(*keyFile)
 	if err != nil {
 		utils.FatalErrMsg(err, "cannot load key from %s", *keyFile)
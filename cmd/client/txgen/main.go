@@ -1,10 +1,15 @@
 package main
 
 import (
+	"context"
+	"crypto/ecdsa"
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"math/big"
 	"math/rand"
+	"net/http"
+	_ "net/http/pprof"
 	"os"
 	"path"
 	"sync"
@@ -13,8 +18,10 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	bls2 "github.com/harmony-one/bls/ffi/go/bls"
+	"github.com/harmony-one/harmony/accounts/keystore"
 	"github.com/harmony-one/harmony/api/client"
 	proto_node "github.com/harmony-one/harmony/api/proto/node"
+	"github.com/harmony-one/harmony/api/service/networkinfo"
 	"github.com/harmony-one/harmony/common/denominations"
 	"github.com/harmony-one/harmony/consensus"
 	"github.com/harmony-one/harmony/consensus/quorum"
@@ -22,6 +29,7 @@ import (
 	"github.com/harmony-one/harmony/core/types"
 	"github.com/harmony-one/harmony/crypto/bls"
 	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
+	viperconfig "github.com/harmony-one/harmony/internal/configs/viper"
 	"github.com/harmony-one/harmony/internal/genesis"
 	"github.com/harmony-one/harmony/internal/params"
 	"github.com/harmony-one/harmony/internal/shardchain"
@@ -32,6 +40,8 @@ import (
 	"github.com/harmony-one/harmony/p2p/p2pimpl"
 	p2putils "github.com/harmony-one/harmony/p2p/utils"
 	"github.com/harmony-one/harmony/shard"
+	peerstore "github.com/libp2p/go-libp2p-peerstore"
+	"github.com/pkg/errors"
 )
 
 var (
@@ -50,6 +60,7 @@ const (
 type Settings struct {
 	NumOfAddress      int
 	MaxNumTxsPerBatch int
+	Senders           []*ecdsa.PrivateKey // funded accounts sending to each other
 }
 
 func printVersion(me string) {
@@ -65,17 +76,35 @@ var (
 	port            = flag.String("port", "9999", "port of the node.")
 	numTxns         = flag.Int("numTxns", 100, "number of transactions to send per message")
 	logFolder       = flag.String("log_folder", "latest", "the folder collecting the logs of this execution")
+	logMaxSize      = flag.Int("log_max_size", 100, "the max size in megabytes of the log file before it gets rotated")
+	logMaxBackups   = flag.Int("log_max_backups", 10, "the number of rotated log files to keep; 0 keeps all of them")
+	logMaxAge       = flag.Int("log_max_age", 0, "the number of days to keep rotated log files; 0 keeps them forever")
+	logCompress     = flag.Bool("log_compress", true, "gzip rotated log files")
+	pprofPort       = flag.Int("pprof_port", 0, "if not 0, serve the pprof profiles on this port of localhost")
 	duration        = flag.Int("duration", 30, "duration of the tx generation in second. If it's negative, the experiment runs forever.")
 	versionFlag     = flag.Bool("version", false, "Output version info")
+	printConfig     = flag.Bool("print_config", false, "Output the effective config, after applying the environment and config file, in the config file format")
 	crossShardRatio = flag.Int("cross_shard_ratio", 30, "The percentage of cross shard transactions.") //Keeping this for backward compatibility
 	shardIDFlag     = flag.Int("shardID", 0, "The shardID the node belongs to.")
 	// Key file to store the private key
 	keyFile = flag.String("key", "./.txgenkey", "the private key file of the txgen")
 	// logging verbosity
-	verbosity = flag.Int("verbosity", 5, "Logging verbosity: 0=silent, 1=error, 2=warn, 3=info, 4=debug, 5=detail (default: 5)")
+	verbosity  = flag.Int("verbosity", 5, "Logging verbosity: 0=silent, 1=error, 2=warn, 3=info, 4=debug, 5=detail (default: 5)")
+	logVmodule = flag.String("log_vmodule", "", "per-package logging verbosity overriding -verbosity, e.g. consensus=4,p2p/*=2")
+	logFormat  = flag.String("log_format", utils.LogFormatTerminal, "format of the console logs: terminal or json; the log file is always json")
+	// genesis spec of the network and the keys of its funded accounts
+	genesisFile = flag.String("genesis_file", "", "path to the JSON genesis spec the network was started with")
+	keystoreDir = flag.String("keystore", "", "directory of the keys of accounts funded by the genesis spec, which send the transactions; the test accounts if empty")
+	passSrc     = flag.String("pass", "pass:", "passphrase source of the funded accounts (pass:..., env:..., file:..., or stdin)")
+	// connection to the bootnodes, and the peers to fall back to
+	bootnodeRetries        = flag.Int("bootnode_retries", networkinfo.DefaultConnectOptions().Retries, "number of attempts to connect to each bootnode")
+	bootnodeAttemptTimeout = flag.Duration("bootnode_attempt_timeout", networkinfo.DefaultConnectOptions().AttemptTimeout, "timeout of an attempt to connect to a bootnode or peer")
+	bootnodeMaxBackoff     = flag.Duration("bootnode_max_backoff", networkinfo.DefaultConnectOptions().MaxWait, "maximum wait between attempts to connect to a bootnode")
+	staticPeers            p2putils.AddrList
+	joinTimeout            = flag.Duration("join_timeout", time.Minute, "how long to wait for the shard leader to acknowledge txgen joining the shard")
 )
 
-func setUpTXGen() *node.Node {
+func setUpTXGen(spec *core.GenesisSpec) *node.Node {
 	nodePriKey, _, err := utils.LoadKeyFromFile(*keyFile)
 	if err != nil {
 		utils.FatalErrMsg(err, "cannot load key from %s", *keyFile)
@@ -99,6 +128,13 @@ func setUpTXGen() *node.Node {
 		quorum.SuperMajorityVote, uint32(shardID),
 	)
 	consensusObj, err := consensus.New(myhost, uint32(shardID), p2p.Peer{}, nil, decider)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error :%v \n", err)
+		os.Exit(1)
+	}
+	if spec != nil {
+		nodeconfig.GetShardConfig(uint32(shardID)).GenesisFile = *genesisFile
+	}
 	chainDBFactory := &shardchain.MemDBFactory{}
 	txGen := node.New(myhost, consensusObj, chainDBFactory, nil, false) //Changed it : no longer archival node.
 	txGen.Client = client.NewClient(txGen.GetHost(), uint32(shardID))
@@ -106,8 +142,17 @@ func setUpTXGen() *node.Node {
 	genesisShardingConfig := shard.Schedule.InstanceForEpoch(big.NewInt(core.GenesisEpoch))
 	startIdx := 0
 	endIdx := startIdx + genesisShardingConfig.NumNodesPerShard()
+	committee := genesis.HarmonyAccounts[startIdx:endIdx]
+	if spec != nil && spec.ShardCount > 0 {
+		committee = nil
+		for _, validator := range spec.Committee[uint32(shardID)] {
+			committee = append(committee, genesis.DeployAccount{
+				Address: validator.Address, BlsPublicKey: validator.BlsPublicKey,
+			})
+		}
+	}
 	pubs := []*bls2.PublicKey{}
-	for _, acct := range genesis.HarmonyAccounts[startIdx:endIdx] {
+	for _, acct := range committee {
 		pub := &bls2.PublicKey{}
 		if err := pub.DeserializeHexStr(acct.BlsPublicKey); err != nil {
 			fmt.Printf("Can not deserialize public key. err: %v", err)
@@ -122,6 +167,8 @@ func setUpTXGen() *node.Node {
 	} else {
 		txGen.NodeConfig.SetShardGroupID(nodeconfig.NewGroupIDByShardID(nodeconfig.ShardID(shardID)))
 	}
+	// the leader acknowledges clients and sends them new blocks on this group
+	txGen.NodeConfig.SetClientGroupID(nodeconfig.NewClientGroupIDByShardID(nodeconfig.ShardID(shardID)))
 
 	txGen.NodeConfig.SetIsClient(true)
 
@@ -130,13 +177,35 @@ func setUpTXGen() *node.Node {
 
 func main() {
 	flag.Var(&p2putils.BootNodes, "bootnodes", "a list of bootnode multiaddress")
+	flag.Var(&staticPeers, "peers", "a list of node multiaddresses, e.g. from the config file, to connect to directly if no bootnode is reachable")
 	flag.Parse()
 	if *versionFlag {
 		printVersion(os.Args[0])
 	}
+	// the txgen section of the config file shared with the nodes, falling back
+	// to the settings of the nodes
+	configFileViper, err := viperconfig.CreateConfFileViper("./.hmy", "nodeconfig", "json")
+	if err != nil {
+		utils.FatalErrMsg(err, "cannot read config")
+	}
+	if err := viperconfig.ResetConfFlags(flag.CommandLine, viperconfig.CreateEnvViper(), configFileViper, "txgen"); err != nil {
+		utils.FatalErrMsg(err, "cannot read config")
+	}
+	if *printConfig {
+		if err := viperconfig.PrintConfig(os.Stdout, flag.CommandLine, "txgen"); err != nil {
+			utils.FatalErrMsg(err, "cannot print config")
+		}
+		os.Exit(0)
+	}
 	// Logging setup
+	if err := utils.SetLogFormat(*logFormat); err != nil {
+		utils.FatalErrMsg(err, "cannot set log format")
+	}
 	utils.SetLogContext(*port, *ip)
 	utils.SetLogVerbosity(log.Lvl(*verbosity))
+	if err := utils.SetLogVmodule(*logVmodule); err != nil {
+		utils.FatalErrMsg(err, "cannot set per-package logging verbosity")
+	}
 	if len(p2putils.BootNodes) == 0 {
 		bootNodeAddrs, err := p2putils.StringsToAddrs(p2putils.DefaultBootNodeAddrStrings)
 		if err != nil {
@@ -154,17 +223,68 @@ func main() {
 		Int("cx ratio", *crossShardRatio).
 		Msg("Cross Shard Ratio Is Set But not used")
 
-	// TODO(Richard): refactor this chuck to a single method
-	// Setup a logger to stdout and log file.
-	logFileName := fmt.Sprintf("./%v/txgen.log", *logFolder)
-	h := log.MultiHandler(
-		log.StreamHandler(os.Stdout, log.TerminalFormat(false)),
-		log.Must.FileHandler(logFileName, log.LogfmtFormat()), // Log to file
-	)
-	log.Root().SetHandler(h)
-	txGen := setUpTXGen()
-	txGen.ServiceManagerSetup()
+	// Log to the file as well, behind the verbosity and vmodule filters.
+	utils.AddRotatingLogFile(fmt.Sprintf("./%v/txgen.log", *logFolder), utils.LogRotation{
+		MaxSize:    *logMaxSize,
+		MaxBackups: *logMaxBackups,
+		MaxAge:     *logMaxAge,
+		Compress:   *logCompress,
+	})
+	if *pprofPort != 0 {
+		addr := fmt.Sprintf("127.0.0.1:%d", *pprofPort)
+		go func() {
+			if err := http.ListenAndServe(addr, nil); err != nil {
+				utils.Logger().Error().Err(err).Str("addr", addr).Msg("pprof server failed")
+			}
+		}()
+	}
+	var spec *core.GenesisSpec
+	if *genesisFile != "" {
+		if spec, err = core.ReadGenesisSpec(*genesisFile); err != nil {
+			utils.FatalErrMsg(err, "cannot read genesis spec")
+		}
+		schedule, err := spec.ShardingSchedule()
+		if err != nil {
+			utils.FatalErrMsg(err, "invalid genesis sharding config")
+		}
+		if schedule != nil {
+			shard.Schedule = schedule
+			nodeconfig.SetShardingSchedule(schedule)
+		}
+	}
+	txGen := setUpTXGen(spec)
+	setting.Senders = txGen.TestBankKeys
+	if *keystoreDir != "" {
+		if spec == nil {
+			utils.FatalErrMsg(errors.New("no genesis spec"), "cannot use funded accounts")
+		}
+		pass, err := utils.GetPassphraseFromSource(*passSrc)
+		if err != nil {
+			utils.FatalErrMsg(err, "cannot read passphrase")
+		}
+		if setting.Senders, err = fundedKeys(spec, uint32(shardID), *keystoreDir, pass); err != nil {
+			utils.FatalErrMsg(err, "cannot use funded accounts")
+		}
+	}
+	txGen.BootnodeConnectOptions = networkinfo.ConnectOptions{
+		Retries:        *bootnodeRetries,
+		AttemptTimeout: *bootnodeAttemptTimeout,
+		MaxWait:        *bootnodeMaxBackoff,
+	}
+	if err := txGen.ServiceManagerSetup(); err != nil {
+		utils.FatalErrMsg(err, "cannot set up txgen services")
+	}
 	txGen.RunServices()
+	go txGen.StartServer()
+	if err := txGen.NetworkInfoErr(); err != nil {
+		if len(staticPeers) == 0 {
+			utils.FatalErrMsg(err, "cannot join the network; give -peers to connect to directly")
+		}
+		utils.Logger().Warn().Err(err).Msg("cannot join the network through the bootnodes, connecting to peers")
+		if err := connectPeers(txGen.GetHost(), staticPeers, *bootnodeAttemptTimeout); err != nil {
+			utils.FatalErrMsg(err, "cannot join the network")
+		}
+	}
 	start := time.Now()
 	totalTime := float64(*duration)
 	utils.Logger().Debug().
@@ -213,10 +333,19 @@ syncLoop:
 					Uint64("incoming block", block.NumberU64()).
 					Msg("Got block from leader")
 				if block.NumberU64()-txGen.Blockchain().CurrentBlock().NumberU64() == 1 {
+					if err := verifyBlock(txGen.Blockchain(), block); err != nil {
+						utils.Logger().Warn().
+							Err(err).
+							Uint64("blockNum", block.NumberU64()).
+							Str("blockHash", block.Hash().Hex()).
+							Msg("Rejecting invalid block from leader")
+						continue
+					}
 					if _, err := txGen.Blockchain().InsertChain([]*types.Block{block}, true); err != nil {
 						utils.Logger().Error().
 							Err(err).
 							Msg("Error when adding new block")
+						continue
 					}
 					stateMutex.Lock()
 					if err := txGen.Worker.UpdateCurrent(); err != nil {
@@ -230,12 +359,18 @@ syncLoop:
 			}
 		}
 	}
-	txGen.Client.UpdateBlocks = updateBlocksFunc
-	// Start the client server to listen to leader's message
+	// only blocks sent with their commit signatures can be verified
+	txGen.Client.UpdateBlocksWithSig = updateBlocksFunc
+	// Send transactions once the leader has admitted us into the shard
+	ack, err := txGen.WaitForJoinAck(*joinTimeout)
+	if err != nil {
+		utils.FatalErrMsg(err, "cannot join shard %d", shardID)
+	}
+	utils.Logger().Info().
+		Uint32("shardID", ack.ShardID).
+		Str("role", ack.Node.Role.String()).
+		Msg("[Txgen] joined shard")
 	go func() {
-		// wait for 3 seconds for client to send ping message to leader
-		// FIXME (leo) the readySignal should be set once we really sent ping message to leader
-		time.Sleep(1 * time.Second) // wait for nodes to be ready
 		readySignal <- uint32(shardID)
 	}()
 pushLoop:
@@ -259,7 +394,6 @@ pushLoop:
 					go func() {
 						readySignal <- uint32(shardID)
 						utils.Logger().Debug().Msg("Same blockchain height so readySignal generated")
-						time.Sleep(3 * time.Second) // wait for nodes to be ready
 					}()
 				}
 			}
@@ -282,6 +416,29 @@ pushLoop:
 	}
 }
 
+// verifyBlock checks that a block received from the network extends the
+// current head of the mirrored chain and that its commit signature, sent
+// along with it, comes from a quorum of its committee.
+func verifyBlock(chain *core.BlockChain, block *types.Block) error {
+	if current := chain.CurrentBlock(); block.ParentHash() != current.Hash() {
+		return errors.Errorf(
+			"block %d does not extend current block %s", block.NumberU64(), current.Hash().Hex(),
+		)
+	}
+	sigAndBitmap := block.GetCurrentCommitSig()
+	if len(sigAndBitmap) <= shard.BLSSignatureSizeInBytes {
+		return errors.Errorf("block %d has no commit signature", block.NumberU64())
+	}
+	if err := chain.Engine().VerifyHeaderWithSignature(
+		chain, block.Header(),
+		sigAndBitmap[:shard.BLSSignatureSizeInBytes], sigAndBitmap[shard.BLSSignatureSizeInBytes:],
+		true,
+	); err != nil {
+		return errors.Wrapf(err, "invalid commit signature of block %d", block.NumberU64())
+	}
+	return nil
+}
+
 // SendTxsToShard sends txs to shard, currently just to beacon shard
 func SendTxsToShard(clientNode *node.Node, txs types.Transactions, shardID uint32) {
 	msg := proto_node.ConstructTransactionListMessageAccount(txs)
@@ -299,25 +456,76 @@ func SendTxsToShard(clientNode *node.Node, txs types.Transactions, shardID uint3
 	}
 }
 
+// connectPeers connects to the peers at the given addresses, and returns an
+// error if none of them can be connected.
+func connectPeers(host p2p.Host, addrs p2putils.AddrList, timeout time.Duration) error {
+	connected := 0
+	for _, addr := range addrs {
+		peerInfo, err := peerstore.InfoFromP2pAddr(addr)
+		if err != nil {
+			return errors.Wrapf(err, "invalid peer address %s", addr)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		err = host.GetP2PHost().Connect(ctx, *peerInfo)
+		cancel()
+		if err != nil {
+			utils.Logger().Warn().Err(err).Str("addr", addr.String()).Msg("cannot connect to peer")
+			continue
+		}
+		connected++
+	}
+	if connected == 0 {
+		return errors.Errorf("cannot connect to any of %d peers", len(addrs))
+	}
+	return nil
+}
+
+// fundedKeys returns the keys in keystoreDir of the accounts funded on the
+// shard by the genesis spec.
+func fundedKeys(spec *core.GenesisSpec, shardID uint32, keystoreDir, pass string) ([]*ecdsa.PrivateKey, error) {
+	ks := keystore.NewKeyStore(keystoreDir, keystore.StandardScryptN, keystore.StandardScryptP)
+	var keys []*ecdsa.PrivateKey
+	for _, account := range ks.Accounts() {
+		if _, ok := spec.Alloc[shardID][account.Address]; !ok {
+			continue
+		}
+		keyJSON, err := ioutil.ReadFile(account.URL.Path)
+		if err != nil {
+			return nil, err
+		}
+		key, err := keystore.DecryptKey(keyJSON, pass)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot decrypt %s", account.URL.Path)
+		}
+		keys = append(keys, key.PrivateKey)
+	}
+	if len(keys) == 0 {
+		return nil, errors.Errorf("no key in %s of accounts funded on shard %d", keystoreDir, shardID)
+	}
+	return keys, nil
+}
+
 // GenerateSimulatedTransactionsAccount generates simulated transaction for account model.
 func GenerateSimulatedTransactionsAccount(shardID uint32, node *node.Node, setting Settings) (types.Transactions, error) {
 	TxnsToGenerate := setting.MaxNumTxsPerBatch // TODO: make use of settings
+	senders := setting.Senders
+	numSenders := len(senders)
 	txs := make([]*types.Transaction, TxnsToGenerate)
-	rounds := (TxnsToGenerate / 100)
-	remainder := TxnsToGenerate % 100
-	for i := 0; i < 100; i++ {
-		baseNonce := node.Worker.GetCurrentState().GetNonce(crypto.PubkeyToAddress(node.TestBankKeys[i].PublicKey))
+	rounds := (TxnsToGenerate / numSenders)
+	remainder := TxnsToGenerate % numSenders
+	for i := 0; i < numSenders; i++ {
+		baseNonce := node.Worker.GetCurrentState().GetNonce(crypto.PubkeyToAddress(senders[i].PublicKey))
 		for j := 0; j < rounds; j++ {
-			randomUserAddress := crypto.PubkeyToAddress(node.TestBankKeys[rand.Intn(100)].PublicKey)
+			randomUserAddress := crypto.PubkeyToAddress(senders[rand.Intn(numSenders)].PublicKey)
 			randAmount := rand.Float32()
-			tx, _ := types.SignTx(types.NewTransaction(baseNonce+uint64(j), randomUserAddress, shardID, big.NewInt(int64(denominations.One*randAmount)), params.TxGas, nil, nil), types.HomesteadSigner{}, node.TestBankKeys[i])
-			txs[100*j+i] = tx
+			tx, _ := types.SignTx(types.NewTransaction(baseNonce+uint64(j), randomUserAddress, shardID, big.NewInt(int64(denominations.One*randAmount)), params.TxGas, nil, nil), types.HomesteadSigner{}, senders[i])
+			txs[numSenders*j+i] = tx
 		}
 		if i < remainder {
-			randomUserAddress := crypto.PubkeyToAddress(node.TestBankKeys[rand.Intn(100)].PublicKey)
+			randomUserAddress := crypto.PubkeyToAddress(senders[rand.Intn(numSenders)].PublicKey)
 			randAmount := rand.Float32()
-			tx, _ := types.SignTx(types.NewTransaction(baseNonce+uint64(rounds), randomUserAddress, shardID, big.NewInt(int64(denominations.One*randAmount)), params.TxGas, nil, nil), types.HomesteadSigner{}, node.TestBankKeys[i])
-			txs[100*rounds+i] = tx
+			tx, _ := types.SignTx(types.NewTransaction(baseNonce+uint64(rounds), randomUserAddress, shardID, big.NewInt(int64(denominations.One*randAmount)), params.TxGas, nil, nil), types.HomesteadSigner{}, senders[i])
+			txs[numSenders*rounds+i] = tx
 		}
 	}
 	return txs, nil
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/hex"
 	"flag"
@@ -11,6 +12,8 @@ import (
 	"os"
 	"path"
 	"regexp"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -19,14 +22,18 @@ import (
 	ffi_bls "github.com/harmony-one/bls/ffi/go/bls"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/harmony-one/harmony/accounts"
 	"github.com/harmony-one/harmony/accounts/keystore"
 	"github.com/harmony-one/harmony/api/client"
 	clientService "github.com/harmony-one/harmony/api/client/service"
 	proto_node "github.com/harmony-one/harmony/api/proto/node"
+	"github.com/harmony-one/harmony/api/service/clientsupport"
 	"github.com/harmony-one/harmony/common/denominations"
 	"github.com/harmony-one/harmony/core"
 	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/hmyclient"
 	"github.com/harmony-one/harmony/internal/blsgen"
 	common2 "github.com/harmony-one/harmony/internal/common"
 	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
@@ -61,9 +68,12 @@ type AccountState struct {
 
 const (
 	rpcRetry          = 3
+	inclusionPoll     = 2 * time.Second
+	rpcHTTPPortOffset = 500 // same as in node/rpc.go
 	defaultConfigFile = ".hmy/wallet.ini"
 	defaultProfile    = "main"
 	keystoreDir       = ".hmy/keystore"
+	addressBookFile   = ".hmy/addressbook.json"
 )
 
 var (
@@ -73,7 +83,14 @@ var (
 	// -pass takes on "pass:password", "env:var", "file:pathname",
 	// "fd:number", or "stdin" form.
 	// See “PASS PHRASE ARGUMENTS” section of openssl(1) for details.
-	newCommandPassPtr = newCommand.String("pass", "", "how to get passphrase for the key")
+	newCommandPassPtr     = newCommand.String("pass", "", "how to get passphrase for the key")
+	newCommandMnemonicPtr = newCommand.Bool("mnemonic", false, "Derive the account from a new BIP-39 mnemonic")
+
+	// Recover subcommands
+	recoverCommand            = flag.NewFlagSet("recover", flag.ExitOnError)
+	recoverCommandMnemonicPtr = recoverCommand.String("mnemonic", "", "how to get the BIP-39 mnemonic to recover accounts from")
+	recoverCommandCountPtr    = recoverCommand.Uint("count", 1, "The number of accounts to recover")
+	recoverCommandPassPtr     = recoverCommand.String("pass", "", "how to get passphrase for the recovered keys")
 
 	// List subcommands
 	listCommand = flag.NewFlagSet("list", flag.ExitOnError)
@@ -102,6 +119,13 @@ var (
 	transferInputDataPtr  = transferCommand.String("inputData", "", "Base64-encoded input data to embed in the transaction")
 	transferSenderPassPtr = transferCommand.String("pass", "", "Passphrase of the sender's private key")
 	waitForColoredBalance = transferCommand.Bool("waitThenBal", false, "")
+	transferNoncePtr      = transferCommand.Int64("nonce", -1, "Specify the nonce; -1 uses the sender's current nonce")
+	transferWaitPtr       = transferCommand.Uint("wait", 0, "Wait up to this many seconds for the transaction to be included and, across shards, credited")
+	transferSignOnlyPtr   = transferCommand.Bool("signOnly", false, "Only sign the transaction and print it as a raw hex blob, without network access")
+
+	sendRawCommand        = flag.NewFlagSet("sendRaw", flag.ExitOnError)
+	sendRawShardIDPtr     = sendRawCommand.Int("shardID", -1, "Specify the shard ID of the transaction")
+	sendRawTransactionPtr = sendRawCommand.String("tx", "", "The signed transaction as a raw hex blob")
 
 	freeTokenCommand    = flag.NewFlagSet("getFreeToken", flag.ExitOnError)
 	freeTokenAddressPtr = freeTokenCommand.String("address", "", "Specify the account address to receive the free token")
@@ -122,6 +146,12 @@ var (
 	getBlsPublicCommand = flag.NewFlagSet("getBlsPublic", flag.ExitOnError)
 	blsKey2             = getBlsPublicCommand.String("key", "", "The raw private key.")
 	blsFile2            = getBlsPublicCommand.String("file", "", "The encrypted bls file.")
+
+	aliasCommand    = flag.NewFlagSet("alias", flag.ExitOnError)
+	aliasNamePtr    = aliasCommand.String("name", "", "The alias name")
+	aliasAddressPtr = aliasCommand.String("address", "", "The address the alias stands for")
+	aliasShardIDPtr = aliasCommand.Int("shardID", -1, "The shard the address is usually used on; -1 if unknown")
+	aliasRemovePtr  = aliasCommand.Bool("remove", false, "Remove the alias")
 )
 
 var (
@@ -155,24 +185,28 @@ func main() {
 		fmt.Println("    1. new           - Generates a new account and store the private key locally")
 		fmt.Println("        --nopass         - The private key has no passphrase (for test only)")
 		fmt.Println("        --pass           - The passphrase for the private key, in the format of: pass:password, env:var, file:pathname, fd:number, or stdin")
+		fmt.Println("        --mnemonic       - Derive the account from a new BIP-39 mnemonic, which is printed for backup")
 		fmt.Println("    2. list          - Lists all accounts in local keystore")
 		fmt.Println("    3. removeAll     - Removes all accounts in local keystore")
 		fmt.Println("    4. import        - Imports a new account by private key")
 		fmt.Println("        --pass           - The passphrase of the private key to import")
 		fmt.Println("        --privateKey     - The private key to import")
 		fmt.Println("    5. balances      - Shows the balances of all addresses or specific address")
-		fmt.Println("        --address        - The address to check balance for")
+		fmt.Println("        --address        - The address or alias to check balance for")
 		fmt.Println("    6. getFreeToken  - Gets free token on each shard")
-		fmt.Println("        --address        - The free token receiver account's address")
+		fmt.Println("        --address        - The free token receiver account's address or alias")
 		fmt.Println("    7. transfer      - Transfer token from one account to another")
-		fmt.Println("        --from           - The sender account's address or index in the local keystore")
-		fmt.Println("        --to             - The receiver account's address")
+		fmt.Println("        --from           - The sender account's address, index in the local keystore, or alias")
+		fmt.Println("        --to             - The receiver account's address or alias")
 		fmt.Println("        --amount         - The amount of token to transfer")
 		fmt.Println("        --shardID        - The shard Id for the transfer")
 		fmt.Println("        --toShardID      - The destination shard Id for the transfer")
 		fmt.Println("        --inputData      - Base64-encoded input data to embed in the transaction")
 		fmt.Println("        --pass           - Passphrase of sender's private key")
 		fmt.Println("        --waitThenBal    - Wait after the transfer with colored balances output")
+		fmt.Println("        --nonce          - The nonce of the transaction; default is the sender's current nonce")
+		fmt.Println("        --wait           - Seconds to wait for the transaction to be included and, for a cross-shard transfer, credited in the destination shard; 0 does not wait")
+		fmt.Println("        --signOnly       - Sign offline and print the raw transaction for sendRaw; requires --nonce")
 		fmt.Println("    8. export        - Export account key to a new file")
 		fmt.Println("        --account        - Specify the account to export. Empty will export every key.")
 		fmt.Println("    9. exportPriKey  - Export account private key")
@@ -189,6 +223,18 @@ func main() {
 		fmt.Println("   14. getBlsPublic   - Show Bls public key given raw private bls key.")
 		fmt.Println("        --key            - Raw private key.")
 		fmt.Println("        --file           - encrypted bls file.")
+		fmt.Println("   15. recover       - Recovers accounts from a BIP-39 mnemonic")
+		fmt.Println("        --mnemonic       - How to get the mnemonic, in the same format as --pass; prompted for if omitted")
+		fmt.Println("        --count          - The number of accounts to recover (default: 1)")
+		fmt.Println("        --pass           - The passphrase for the private keys, in the same format as for new")
+		fmt.Println("   16. sendRaw       - Broadcasts a transaction signed with transfer --signOnly")
+		fmt.Println("        --shardID        - The shard ID of the transaction")
+		fmt.Println("        --tx             - The raw transaction in hex")
+		fmt.Println("   17. alias         - Names an address; the name can be used wherever an address is expected")
+		fmt.Println("        --name           - The alias name; without --name, lists all aliases")
+		fmt.Println("        --address        - The address the alias stands for")
+		fmt.Println("        --shardID        - The shard the address is usually used on, used when a transfer omits the shard")
+		fmt.Println("        --remove         - Removes the alias")
 		os.Exit(1)
 	}
 
@@ -228,6 +274,8 @@ ARG:
 		printVersion(os.Args[0])
 	case "new":
 		processNewCommnad()
+	case "recover":
+		processRecoverCommand()
 	case "list":
 		processListCommand()
 	case "export":
@@ -249,6 +297,9 @@ ARG:
 	case "transfer":
 		readProfile(profile)
 		processTransferCommand()
+	case "sendRaw":
+		readProfile(profile)
+		processSendRawCommand()
 	case "format":
 		formatAddressCommand()
 	case "blsRecovery":
@@ -257,6 +308,8 @@ ARG:
 		importBls()
 	case "getBlsPublic":
 		getBlsPublic()
+	case "alias":
+		processAliasCommand()
 	default:
 		fmt.Printf("Unknown action: %s\n", os.Args[1])
 		flag.PrintDefaults()
@@ -300,7 +353,10 @@ func createWalletNode() *node.Node {
 	// we need to understand the impact to bootnode DHT with this dummy host ip added
 	port := fmt.Sprintf("%d", 16999+rand.Intn(1000))
 	self := p2p.Peer{IP: "127.0.0.1", Port: port}
-	priKey, _, _ := utils.GenKeyP2P("127.0.0.1", port)
+	priKey, _, err := utils.GenKeyP2PRand()
+	if err != nil {
+		utils.FatalErrMsg(err, "cannot generate network key")
+	}
 	host, err := p2pimpl.NewHost(&self, priKey)
 	if err != nil {
 		utils.FatalErrMsg(err, "cannot initialize network")
@@ -312,7 +368,9 @@ func createWalletNode() *node.Node {
 	w.NodeConfig.SetRole(nodeconfig.ClientNode)
 	netType := nodeconfig.NetworkType(walletProfile.Network)
 	nodeconfig.SetNetworkType(netType)
-	w.ServiceManagerSetup()
+	if err := w.ServiceManagerSetup(); err != nil {
+		utils.FatalErrMsg(err, "cannot set up wallet services")
+	}
 	w.RunServices()
 	return w
 }
@@ -342,6 +400,22 @@ func processNewCommnad() {
 		}
 	}
 
+	if *newCommandMnemonicPtr {
+		mnemonic, err := keystore.NewMnemonic()
+		if err != nil {
+			utils.FatalErrMsg(err, "cannot generate mnemonic")
+		}
+		account, err := ks.ImportMnemonic(mnemonic, 0, password)
+		if err != nil {
+			utils.FatalErrMsg(err, "cannot create account from mnemonic")
+		}
+		fmt.Printf("account: %s\n", common2.MustAddressToBech32(account.Address))
+		fmt.Printf("URL: %s\n", account.URL)
+		fmt.Println("Write down the mnemonic below; it restores this and further accounts with the recover action:")
+		fmt.Println(mnemonic)
+		return
+	}
+
 	account, err := ks.NewAccount(password)
 	if err != nil {
 		fmt.Printf("new account error: %v\n", err)
@@ -350,6 +424,42 @@ func processNewCommnad() {
 	fmt.Printf("URL: %s\n", account.URL)
 }
 
+func processRecoverCommand() {
+	if err := recoverCommand.Parse(os.Args[2:]); err != nil {
+		fmt.Println(ctxerror.New("failed to parse flags").WithCause(err))
+		return
+	}
+	mnemonic := ""
+	if src := *recoverCommandMnemonicPtr; src == "" {
+		mnemonic = utils.AskForPassphrase("Mnemonic: ")
+	} else if newMnemonic, err := utils.GetPassphraseFromSource(src); err != nil {
+		fmt.Printf("Cannot read mnemonic: %s\n", err)
+		os.Exit(3)
+	} else {
+		mnemonic = newMnemonic
+	}
+	// files and stdin usually end with a newline
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	password := ""
+	if pass := *recoverCommandPassPtr; pass == "" {
+		password = utils.AskForPassphrase("Passphrase: ")
+	} else if newPass, err := utils.GetPassphraseFromSource(pass); err != nil {
+		fmt.Printf("Cannot read passphrase: %s\n", err)
+		os.Exit(3)
+	} else {
+		password = newPass
+	}
+
+	for i := uint32(0); i < uint32(*recoverCommandCountPtr); i++ {
+		account, err := ks.ImportMnemonic(mnemonic, i, password)
+		if err != nil {
+			fmt.Printf("account %d: %v\n", i, err)
+			continue
+		}
+		fmt.Printf("account %d: %s\n", i, common2.MustAddressToBech32(account.Address))
+	}
+}
+
 func _exportAccount(account accounts.Account) {
 	fmt.Printf("account: %s\n", common2.MustAddressToBech32(account.Address))
 	fmt.Printf("URL: %s\n", account.URL)
@@ -511,7 +621,9 @@ func showAllBalances(sender, receiver string, fromS, toS int) {
 		} else {
 			fmt.Printf("    Address: %s\n", asBech32)
 		}
-		for shardID, balanceNonce := range FetchBalance(account.Address) {
+		balances := FetchBalance(account.Address)
+		total, complete := totalBalance(balances)
+		for shardID, balanceNonce := range balances {
 			if balanceNonce != nil {
 				if shardID == fromS && asBech32 == sender {
 					color.Yellow("    Balance in Shard %d:  %s, nonce: %v \n", shardID, convertBalanceIntoReadableFormat(balanceNonce.balance), balanceNonce.nonce)
@@ -525,6 +637,7 @@ func showAllBalances(sender, receiver string, fromS, toS int) {
 				fmt.Printf("    Balance in Shard %d:  connection failed", shardID)
 			}
 		}
+		printTotalBalance(total, complete)
 	}
 }
 
@@ -536,8 +649,9 @@ func processBalancesCommand() {
 	if *balanceAddressPtr == "" {
 		showAllBalances("", "", -1, -1)
 	} else {
-		address := common2.ParseAddr(*balanceAddressPtr)
-		valid, errorMessage := validateAddress(*balanceAddressPtr, address, "")
+		addressStr, _ := resolveAlias(*balanceAddressPtr)
+		address := common2.ParseAddr(addressStr)
+		valid, errorMessage := validateAddress(addressStr, address, "")
 
 		if !valid && len(errorMessage) > 0 {
 			fmt.Println(errorMessage)
@@ -545,13 +659,38 @@ func processBalancesCommand() {
 		}
 
 		fmt.Printf("Account: %s:\n", common2.MustAddressToBech32(address))
-		for shardID, balanceNonce := range FetchBalance(address) {
+		balances := FetchBalance(address)
+		total, complete := totalBalance(balances)
+		for shardID, balanceNonce := range balances {
 			if balanceNonce != nil {
 				fmt.Printf("    Balance in Shard %d:  %s, nonce: %v \n", shardID, convertBalanceIntoReadableFormat(balanceNonce.balance), balanceNonce.nonce)
 			} else {
 				fmt.Printf("    Balance in Shard %d:  connection failed \n", shardID)
 			}
 		}
+		printTotalBalance(total, complete)
+	}
+}
+
+// totalBalance sums up the balances of an account fetched from every shard.
+// complete is false if some shard could not be reached.
+func totalBalance(balances []*AccountState) (total *big.Int, complete bool) {
+	total, complete = big.NewInt(0), true
+	for _, balanceNonce := range balances {
+		if balanceNonce == nil {
+			complete = false
+			continue
+		}
+		total.Add(total, balanceNonce.balance)
+	}
+	return total, complete
+}
+
+func printTotalBalance(total *big.Int, complete bool) {
+	if complete {
+		fmt.Printf("    Total Balance:  %s \n", convertBalanceIntoReadableFormat(total))
+	} else {
+		fmt.Printf("    Total Balance:  %s (excluding unreachable shards) \n", convertBalanceIntoReadableFormat(total))
 	}
 }
 
@@ -682,8 +821,9 @@ func processGetFreeToken() {
 	if *freeTokenAddressPtr == "" {
 		fmt.Println("Error: --address is required")
 	} else {
-		address := common2.ParseAddr(*freeTokenAddressPtr)
-		valid, errorMessage := validateAddress(*freeTokenAddressPtr, address, "")
+		addressStr, _ := resolveAlias(*freeTokenAddressPtr)
+		address := common2.ParseAddr(addressStr)
+		valid, errorMessage := validateAddress(addressStr, address, "")
 
 		if !valid && len(errorMessage) > 0 {
 			fmt.Println(errorMessage)
@@ -699,12 +839,18 @@ func processTransferCommand() {
 		fmt.Println(ctxerror.New("Failed to parse flags").WithCause(err))
 		return
 	}
-	sender := *transferSenderPtr
-	receiver := *transferReceiverPtr
+	sender, senderShardID := resolveAlias(*transferSenderPtr)
+	receiver, receiverShardID := resolveAlias(*transferReceiverPtr)
 	amount := *transferAmountPtr
 	gasPrice := *transferGasPricePtr
 	shardID := *transferShardIDPtr
 	toShardID := *transferToShardIDPtr
+	if shardID == -1 && senderShardID != nil {
+		shardID = int(*senderShardID)
+	}
+	if toShardID == -1 && receiverShardID != nil {
+		toShardID = int(*receiverShardID)
+	}
 	base64InputData := *transferInputDataPtr
 	senderPass := *transferSenderPassPtr
 
@@ -746,22 +892,34 @@ func processTransferCommand() {
 		return
 	}
 
-	shardIDToAccountState := FetchBalance(senderAddress)
-
-	state := shardIDToAccountState[shardID]
-	if state == nil {
-		fmt.Printf("Failed connecting to the shard %d\n", shardID)
+	var nonce uint64
+	if *transferNoncePtr >= 0 {
+		nonce = uint64(*transferNoncePtr)
+	} else if *transferSignOnlyPtr {
+		fmt.Println("Please specify the --nonce of the transaction to sign offline")
 		return
 	}
 
-	balance := state.balance
-	balance = balance.Div(balance, big.NewInt(denominations.Nano))
-	if amount > float64(balance.Uint64())/denominations.Nano {
-		fmt.Printf("Balance is not enough for the transfer, current balance is %.6f\n", float64(balance.Uint64())/denominations.Nano)
-		return
-	}
+	// an offline signer can neither check the balance nor look up the nonce
+	if !*transferSignOnlyPtr {
+		shardIDToAccountState := FetchBalance(senderAddress)
 
-	walletNode := createWalletNode()
+		state := shardIDToAccountState[shardID]
+		if state == nil {
+			fmt.Printf("Failed connecting to the shard %d\n", shardID)
+			return
+		}
+
+		balance := state.balance
+		balance = balance.Div(balance, big.NewInt(denominations.Nano))
+		if amount > float64(balance.Uint64())/denominations.Nano {
+			fmt.Printf("Balance is not enough for the transfer, current balance is %.6f\n", float64(balance.Uint64())/denominations.Nano)
+			return
+		}
+		if *transferNoncePtr < 0 {
+			nonce = state.nonce
+		}
+	}
 
 	amountBigInt := big.NewInt(int64(amount * denominations.Nano))
 	amountBigInt = amountBigInt.Mul(amountBigInt, big.NewInt(denominations.Nano))
@@ -779,7 +937,7 @@ func processTransferCommand() {
 	gasPriceBigInt = gasPriceBigInt.Mul(gasPriceBigInt, big.NewInt(denominations.Nano))
 
 	tx = types.NewCrossShardTransaction(
-		state.nonce, &receiverAddress, fromShard, toShard, amountBigInt,
+		nonce, &receiverAddress, fromShard, toShard, amountBigInt,
 		gas, gasPriceBigInt, inputData)
 
 	account, err := ks.Find(accounts.Account{Address: senderAddress})
@@ -813,6 +971,141 @@ func processTransferCommand() {
 		return
 	}
 
+	if *transferSignOnlyPtr {
+		rawTx, err := rlp.EncodeToBytes(tx)
+		if err != nil {
+			fmt.Printf("cannot encode transaction: %v\n", err)
+			return
+		}
+		fmt.Printf("Signed transaction for shard %d: %s\n", shardID, hexutil.Encode(rawTx))
+		return
+	}
+
+	wait := time.Duration(*transferWaitPtr) * time.Second
+	walletNode := createWalletNode()
+	if err := submitTransaction(tx, walletNode, uint32(shardID)); err != nil {
+		fmt.Println(ctxerror.New("submitTransaction failed",
+			"tx", tx, "shardID", shardID).WithCause(err))
+		return
+	}
+
+	if wait == 0 {
+		return
+	}
+	inclusion := waitForInclusion(tx.Hash(), shardID, wait)
+	if inclusion == nil {
+		fmt.Printf("Transaction %s not included in shard %d after %v\n", tx.Hash().Hex(), shardID, wait)
+		return
+	}
+	if inclusion.Failed() {
+		fmt.Printf("Transaction %s FAILED in shard %d at block %d\n", tx.Hash().Hex(), shardID, inclusion.BlockNumber)
+		return
+	}
+	fmt.Printf("Transaction %s included in shard %d at block %d\n", tx.Hash().Hex(), shardID, inclusion.BlockNumber)
+	if fromShard == toShard {
+		return
+	}
+	cx := waitForCXReceipt(tx.Hash(), toShardID, wait)
+	if cx == nil {
+		fmt.Printf("Cross-shard receipt of transaction %s not received in shard %d after %v\n", tx.Hash().Hex(), toShardID, wait)
+		return
+	}
+	fmt.Printf("Cross-shard receipt of transaction %s received in shard %d at block %d, crediting %s ONE\n",
+		tx.Hash().Hex(), toShardID, cx.BlockNumber, convertBalanceIntoReadableFormat(cx.Amount))
+}
+
+// waitForInclusion polls the RPC servers of the given shard for the receipt
+// of the transaction until it has been included in a block, or until the
+// timeout expires, and returns the block and the status of the transaction.
+func waitForInclusion(hash common.Hash, shardID int, timeout time.Duration) *hmyclient.TransactionInclusion {
+	for deadline := time.Now().Add(timeout); time.Now().Before(deadline); time.Sleep(inclusionPoll) {
+		for _, server := range walletProfile.RPCServer[shardID] {
+			if inclusion := fetchInclusion(server, hash); inclusion != nil {
+				return inclusion
+			}
+		}
+	}
+	return nil
+}
+
+// fetchInclusion returns the block including the transaction as seen by the
+// node behind the RPC server, or nil if it is not included or unreachable.
+func fetchInclusion(server p2p.Peer, hash common.Hash) *hmyclient.TransactionInclusion {
+	var inclusion *hmyclient.TransactionInclusion
+	callRPCServer(server, func(ctx context.Context, client *hmyclient.Client) (err error) {
+		inclusion, err = client.TransactionInclusion(ctx, hash)
+		return err
+	})
+	return inclusion
+}
+
+// waitForCXReceipt polls the RPC servers of the destination shard for the
+// cross-shard receipt of the transaction until it has been applied there, or
+// until the timeout expires, and returns the receipt.
+func waitForCXReceipt(hash common.Hash, shardID int, timeout time.Duration) *hmyclient.CXReceipt {
+	for deadline := time.Now().Add(timeout); time.Now().Before(deadline); time.Sleep(inclusionPoll) {
+		for _, server := range walletProfile.RPCServer[shardID] {
+			var cx *hmyclient.CXReceipt
+			callRPCServer(server, func(ctx context.Context, client *hmyclient.Client) (err error) {
+				cx, err = client.CXReceipt(ctx, hash)
+				return err
+			})
+			if cx != nil {
+				return cx
+			}
+		}
+	}
+	return nil
+}
+
+// callRPCServer calls the JSON RPC of the node behind the RPC server; errors
+// are only logged.
+func callRPCServer(server p2p.Peer, call func(context.Context, *hmyclient.Client) error) {
+	// the RPC servers of the profile are client services, whose node serves
+	// its JSON RPC rpcHTTPPortOffset above its own port
+	port, err := strconv.Atoi(server.Port)
+	if err != nil {
+		return
+	}
+	url := fmt.Sprintf("http://%s:%d", server.IP, port-clientsupport.ClientServicePortDiff+rpcHTTPPortOffset)
+	client, err := hmyclient.Dial(url)
+	if err != nil {
+		return
+	}
+	defer client.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), inclusionPoll)
+	defer cancel()
+	if err := call(ctx, client); err != nil {
+		log.Debug("callRPCServer", "server", url, "error", err)
+	}
+}
+
+func processSendRawCommand() {
+	if err := sendRawCommand.Parse(os.Args[2:]); err != nil {
+		fmt.Println(ctxerror.New("Failed to parse flags").WithCause(err))
+		return
+	}
+	shardID := *sendRawShardIDPtr
+	if !validShard(shardID, walletProfile.Shards) {
+		fmt.Println("Please specify a valid shard ID for the transaction (e.g. --shardID=0)")
+		return
+	}
+	rawTx, err := hexutil.Decode(*sendRawTransactionPtr)
+	if err != nil {
+		fmt.Printf("Cannot hex-decode the transaction: %v\n", err)
+		return
+	}
+	tx := new(types.Transaction)
+	if err := rlp.DecodeBytes(rawTx, tx); err != nil {
+		fmt.Printf("Cannot decode the transaction: %v\n", err)
+		return
+	}
+	if tx.ShardID() != uint32(shardID) {
+		fmt.Printf("The transaction is for shard %d, not %d\n", tx.ShardID(), shardID)
+		return
+	}
+
+	walletNode := createWalletNode()
 	if err := submitTransaction(tx, walletNode, uint32(shardID)); err != nil {
 		fmt.Println(ctxerror.New("submitTransaction failed",
 			"tx", tx, "shardID", shardID).WithCause(err))
@@ -994,6 +1287,72 @@ func submitTransaction(tx *types.Transaction, walletNode *node.Node, shardID uin
 	return nil
 }
 
+func loadAddressBook() *wallet.AddressBook {
+	book, err := wallet.LoadAddressBook(addressBookFile)
+	if err != nil {
+		utils.FatalErrMsg(err, "cannot load the address book")
+	}
+	return book
+}
+
+// resolveAlias returns the address and the shard hint of the alias, or the
+// argument itself and no shard if it is not an alias.
+func resolveAlias(nameOrAddress string) (string, *uint32) {
+	if entry, ok := loadAddressBook().Lookup(nameOrAddress); ok {
+		return entry.Address, entry.ShardID
+	}
+	return nameOrAddress, nil
+}
+
+func processAliasCommand() {
+	if err := aliasCommand.Parse(os.Args[2:]); err != nil {
+		fmt.Println(ctxerror.New("Failed to parse flags").WithCause(err))
+		return
+	}
+	book := loadAddressBook()
+	name := *aliasNamePtr
+	switch {
+	case name == "":
+		for _, name := range book.Names() {
+			entry, _ := book.Lookup(name)
+			if entry.ShardID != nil {
+				fmt.Printf("%s: %s (shard %d)\n", name, entry.Address, *entry.ShardID)
+			} else {
+				fmt.Printf("%s: %s\n", name, entry.Address)
+			}
+		}
+		return
+	case *aliasRemovePtr:
+		if !book.Remove(name) {
+			fmt.Printf("No alias named %s\n", name)
+			return
+		}
+	default:
+		address := common2.ParseAddr(*aliasAddressPtr)
+		valid, errorMessage := validateAddress(*aliasAddressPtr, address, "")
+		if !valid && len(errorMessage) > 0 {
+			fmt.Println(errorMessage)
+			return
+		}
+		entry := wallet.AddressEntry{Address: common2.MustAddressToBech32(address)}
+		if shardID := *aliasShardIDPtr; shardID != -1 {
+			if shardID < 0 {
+				fmt.Println("Please specify a valid shard ID for the alias (e.g. --shardID=0)")
+				return
+			}
+			shard := uint32(shardID)
+			entry.ShardID = &shard
+		}
+		if err := book.Set(name, entry); err != nil {
+			fmt.Println(err)
+			return
+		}
+	}
+	if err := book.Save(); err != nil {
+		fmt.Printf("Cannot save the address book: %v\n", err)
+	}
+}
+
 var (
 	addressValidationRegexp = regexp.MustCompile(`(?i)^(one[a-zA-Z0-9]{39})|(0x[a-fA-F0-9]{40})`)
 )
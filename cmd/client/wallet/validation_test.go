@@ -1,6 +1,7 @@
 package main
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/harmony-one/harmony/internal/common"
@@ -49,3 +50,22 @@ func TestIsValidShard(t *testing.T) {
 		}
 	}
 }
+
+func TestTotalBalance(t *testing.T) {
+	tests := []struct {
+		balances []*AccountState
+		total    int64
+		complete bool
+	}{
+		{[]*AccountState{}, 0, true},
+		{[]*AccountState{{balance: big.NewInt(3)}, {balance: big.NewInt(4)}}, 7, true},
+		{[]*AccountState{{balance: big.NewInt(3)}, nil}, 3, false},
+	}
+
+	for _, test := range tests {
+		total, complete := totalBalance(test.balances)
+		if total.Cmp(big.NewInt(test.total)) != 0 || complete != test.complete {
+			t.Errorf("totalBalance() returned %v, %v, expected %v, %v", total, complete, test.total, test.complete)
+		}
+	}
+}
@@ -183,7 +183,10 @@ func createWalletNode() *node.Node {
 	// TODO: potentially, too many dummy IP may flush out good IP address from our bootnode DHT
 	// we need to understand the impact to bootnode DHT with this dummy host ip added
 	self := p2p.Peer{IP: "127.0.0.1", Port: "6999"}
-	priKey, _, _ := utils.GenKeyP2P("127.0.0.1", "6999")
+	priKey, _, err := utils.GenKeyP2PRand()
+	if err != nil {
+		utils.FatalErrMsg(err, "cannot generate network key")
+	}
 	host, err := p2pimpl.NewHost(&self, priKey)
 	if err != nil {
 		utils.FatalErrMsg(err, "cannot initialize network")
@@ -193,7 +196,9 @@ func createWalletNode() *node.Node {
 	w.Client = client.NewClient(w.GetHost(), uint32(shardID))
 
 	w.NodeConfig.SetRole(nodeconfig.ClientNode)
-	w.ServiceManagerSetup()
+	if err := w.ServiceManagerSetup(); err != nil {
+		utils.FatalErrMsg(err, "cannot set up wallet services")
+	}
 	w.RunServices()
 	return w
 }
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const recaptchaVerifyURL = "https://www.google.com/recaptcha/api/siteverify"
+
+// recaptcha verifies the reCAPTCHA response sent in the
+// "g-recaptcha-response" form field of a request.
+type recaptcha struct {
+	secret    string
+	verifyURL string
+	client    *http.Client
+}
+
+func newRecaptcha(secret string) *recaptcha {
+	return &recaptcha{
+		secret:    secret,
+		verifyURL: recaptchaVerifyURL,
+		client:    &http.Client{Timeout: rpcTimeout},
+	}
+}
+
+// Verify asks the reCAPTCHA service whether the response in the request is
+// valid.
+func (c *recaptcha) Verify(r *http.Request) error {
+	response := r.FormValue("g-recaptcha-response")
+	if response == "" {
+		return errors.New("no captcha response")
+	}
+	form := url.Values{"secret": {c.secret}, "response": {response}}
+	if ip, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		form.Set("remoteip", ip)
+	}
+	resp, err := c.client.Post(c.verifyURL, "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+	if err != nil {
+		return errors.Wrap(err, "cannot verify captcha")
+	}
+	defer resp.Body.Close()
+	var result struct {
+		Success    bool     `json:"success"`
+		ErrorCodes []string `json:"error-codes"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return errors.Wrap(err, "cannot decode captcha verification")
+	}
+	if !result.Success {
+		return errors.Errorf("captcha not solved: %s", strings.Join(result.ErrorCodes, ", "))
+	}
+	return nil
+}
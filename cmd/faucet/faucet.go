@@ -0,0 +1,137 @@
+package main
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	common2 "github.com/harmony-one/harmony/internal/common"
+	"github.com/harmony-one/harmony/internal/utils"
+	"github.com/pkg/errors"
+)
+
+var (
+	errInvalidAddress = errors.New("invalid address, expecting one1... or 0x...")
+)
+
+// Verifier checks that a funding request comes from a human, e.g. by a
+// captcha solved on the page that sent it.
+type Verifier interface {
+	Verify(r *http.Request) error
+}
+
+// faucet is the HTTP front end of the faucet. It dispenses funds to any
+// address that asks for them, at most once per cooldown period for a given
+// address and for a given client IP.
+type faucet struct {
+	cooldown time.Duration
+	dispense func(to common.Address) (common.Hash, error)
+	verifier Verifier // nil if requests need not be verified
+
+	mutex   sync.Mutex
+	granted map[string]time.Time // address or client IP -> time of the last grant
+}
+
+func newFaucet(cooldown time.Duration, dispense func(common.Address) (common.Hash, error), verifier Verifier) *faucet {
+	return &faucet{
+		cooldown: cooldown,
+		dispense: dispense,
+		verifier: verifier,
+		granted:  make(map[string]time.Time),
+	}
+}
+
+// allow reports whether none of the keys was granted funds within the
+// cooldown period, and if so records a grant to all of them.
+func (f *faucet) allow(now time.Time, keys ...string) bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	for key, last := range f.granted {
+		if now.Sub(last) >= f.cooldown {
+			delete(f.granted, key)
+		}
+	}
+	for _, key := range keys {
+		if _, ok := f.granted[key]; ok {
+			return false
+		}
+	}
+	for _, key := range keys {
+		f.granted[key] = now
+	}
+	return true
+}
+
+// release forgets the grant to the keys, e.g. because the funds could not
+// be sent after all.
+func (f *faucet) release(keys ...string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	for _, key := range keys {
+		delete(f.granted, key)
+	}
+}
+
+// ServeHTTP handles a POST request with the receiving address in the
+// "address" form field, and replies with the hash of the funding transaction.
+func (f *faucet) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	address, err := parseAddress(r.FormValue("address"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// RemoteAddr rather than a forwarding header, which clients can forge
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	if f.verifier != nil {
+		if err := f.verifier.Verify(r); err != nil {
+			utils.Logger().Info().Err(err).
+				Str("address", address.Hex()).
+				Str("ip", ip).
+				Msg("[Faucet] request not verified")
+			http.Error(w, "request not verified", http.StatusForbidden)
+			return
+		}
+	}
+	keys := []string{address.Hex(), ip}
+	if !f.allow(time.Now(), keys...) {
+		http.Error(w, "funds were already sent recently, try again later", http.StatusTooManyRequests)
+		return
+	}
+	txHash, err := f.dispense(address)
+	if err != nil {
+		f.release(keys...)
+		utils.Logger().Error().Err(err).
+			Str("address", address.Hex()).
+			Msg("[Faucet] cannot send funds")
+		http.Error(w, "cannot send funds", http.StatusInternalServerError)
+		return
+	}
+	utils.Logger().Info().
+		Str("address", address.Hex()).
+		Str("ip", ip).
+		Str("txHash", txHash.Hex()).
+		Msg("[Faucet] sent funds")
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"txHash": txHash.Hex()})
+}
+
+// parseAddress parses a bech32 or hex address.
+func parseAddress(s string) (common.Address, error) {
+	if address, err := common2.Bech32ToAddress(s); err == nil {
+		return address, nil
+	}
+	if common.IsHexAddress(s) {
+		return common.HexToAddress(s), nil
+	}
+	return common.Address{}, errInvalidAddress
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+func fundRequest(address, remoteAddr string) *http.Request {
+	form := url.Values{"address": {address}}
+	r := httptest.NewRequest(http.MethodPost, "/fund", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.RemoteAddr = remoteAddr
+	return r
+}
+
+func TestFaucet(t *testing.T) {
+	const (
+		addr1 = "one1pdv9lrdwl0rg5vglh4xtyrv3wjk3wsqket7zxy"
+		addr2 = "0x0B585F8DaEfBC68a311FbD4CB20d9174aD174016"
+		addr3 = "0x7c41E0668B551f4f902cFaec05B5Bdca68b124CE"
+	)
+	fail := false
+	var sent []common.Address
+	f := newFaucet(time.Hour, func(to common.Address) (common.Hash, error) {
+		if fail {
+			return common.Hash{}, errors.New("node is down")
+		}
+		sent = append(sent, to)
+		return common.Hash{1}, nil
+	}, nil)
+	tests := []struct {
+		address    string
+		remoteAddr string
+		fail       bool
+		code       int
+	}{
+		{"not an address", "1.1.1.1:1", false, http.StatusBadRequest},
+		{addr3, "3.3.3.3:3", true, http.StatusInternalServerError},
+		{addr1, "1.1.1.1:1", false, http.StatusOK},
+		// addr1 and addr2 are the same address
+		{addr2, "2.2.2.2:2", false, http.StatusTooManyRequests},
+		// same client, different port
+		{addr3, "1.1.1.1:2", false, http.StatusTooManyRequests},
+		// a failed transfer does not count
+		{addr3, "3.3.3.3:3", false, http.StatusOK},
+	}
+	for i, test := range tests {
+		fail = test.fail
+		w := httptest.NewRecorder()
+		f.ServeHTTP(w, fundRequest(test.address, test.remoteAddr))
+		if w.Code != test.code {
+			t.Errorf("request %d: got status %d, expected %d", i, w.Code, test.code)
+		}
+	}
+	if len(sent) != 2 {
+		t.Errorf("expected 2 transfers, got %d", len(sent))
+	}
+
+	w := httptest.NewRecorder()
+	f.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/fund?address="+addr1, nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET: got status %d, expected %d", w.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestFaucetAllowAfterCooldown(t *testing.T) {
+	f := newFaucet(time.Minute, nil, nil)
+	now := time.Now()
+	if !f.allow(now, "a") {
+		t.Fatal("first grant refused")
+	}
+	if f.allow(now.Add(59*time.Second), "a") {
+		t.Error("second grant within the cooldown allowed")
+	}
+	if !f.allow(now.Add(time.Minute), "a") {
+		t.Error("grant after the cooldown refused")
+	}
+}
+
+func TestFaucetVerifier(t *testing.T) {
+	service := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		success := r.FormValue("secret") == "secret" && r.FormValue("response") == "solved"
+		json.NewEncoder(w).Encode(map[string]interface{}{"success": success})
+	}))
+	defer service.Close()
+	verifier := newRecaptcha("secret")
+	verifier.verifyURL = service.URL
+
+	sent := 0
+	f := newFaucet(time.Hour, func(to common.Address) (common.Hash, error) {
+		sent++
+		return common.Hash{1}, nil
+	}, verifier)
+	for i, test := range []struct {
+		response string
+		code     int
+	}{
+		{"", http.StatusForbidden},
+		{"wrong", http.StatusForbidden},
+		{"solved", http.StatusOK},
+	} {
+		r := fundRequest("one1pdv9lrdwl0rg5vglh4xtyrv3wjk3wsqket7zxy", "1.1.1.1:1")
+		r.Form = url.Values{"address": {"one1pdv9lrdwl0rg5vglh4xtyrv3wjk3wsqket7zxy"}, "g-recaptcha-response": {test.response}}
+		w := httptest.NewRecorder()
+		f.ServeHTTP(w, r)
+		if w.Code != test.code {
+			t.Errorf("request %d: got status %d, expected %d", i, w.Code, test.code)
+		}
+	}
+	if sent != 1 {
+		t.Errorf("expected 1 transfer, got %d", sent)
+	}
+}
+
+func TestResyncedNonce(t *testing.T) {
+	for _, test := range []struct {
+		cached, pending, expected uint64
+	}{
+		{5, 5, 5},
+		// transfers not yet in the pool of the node
+		{5 + maxNonceGap, 5, 5 + maxNonceGap},
+		// transfers lost
+		{6 + maxNonceGap, 5, 5},
+		// the account was used elsewhere
+		{5, 7, 7},
+	} {
+		if nonce := resyncedNonce(test.cached, test.pending); nonce != test.expected {
+			t.Errorf("resyncedNonce(%d, %d) = %d, expected %d", test.cached, test.pending, nonce, test.expected)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+// faucet dispenses test funds from a configured account to anyone who asks
+// over HTTP, so developers can get tokens on shared testnets.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/harmony-one/harmony/accounts"
+	"github.com/harmony-one/harmony/accounts/keystore"
+	"github.com/harmony-one/harmony/common/denominations"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/hmyclient"
+	common2 "github.com/harmony-one/harmony/internal/common"
+	"github.com/harmony-one/harmony/internal/params"
+	"github.com/harmony-one/harmony/internal/utils"
+	"github.com/pkg/errors"
+)
+
+var (
+	version string
+	builtBy string
+	builtAt string
+	commit  string
+)
+
+const (
+	rpcTimeout = 10 * time.Second
+	// nonceResyncInterval is how often the cached nonce is checked against
+	// the pending nonce of the node
+	nonceResyncInterval = time.Minute
+	// maxNonceGap is how far the cached nonce may run ahead of the pending
+	// nonce before the transactions in between are taken as lost
+	maxNonceGap = 5
+)
+
+func printVersion(me string) {
+	fmt.Fprintf(os.Stderr, "Harmony (C) 2019. %v, version %v-%v (%v %v)\n", path.Base(me), version, commit, builtBy, builtAt)
+	os.Exit(0)
+}
+
+// dispenser signs and submits the funding transactions. It tracks the nonce
+// of the faucet account itself, so that consecutive requests do not have to
+// wait for the previous transfer to be included, and resyncs it with the node
+// periodically in case transfers were dropped or the account was used
+// elsewhere.
+type dispenser struct {
+	client   *hmyclient.Client
+	ks       *keystore.KeyStore
+	account  accounts.Account
+	chainID  *big.Int
+	shardID  uint32
+	amount   *big.Int
+	gasPrice *big.Int
+
+	mutex    sync.Mutex
+	nonce    uint64
+	synced   bool
+	syncedAt time.Time
+}
+
+// resyncedNonce returns the nonce to use next given the cached one and the
+// pending one of the node.
+func resyncedNonce(cached, pending uint64) uint64 {
+	if pending > cached || cached-pending > maxNonceGap {
+		return pending
+	}
+	return cached
+}
+
+// syncNonce looks up the pending nonce of the faucet account if it was not
+// looked up recently.
+func (d *dispenser) syncNonce(ctx context.Context) error {
+	if d.synced && time.Since(d.syncedAt) < nonceResyncInterval {
+		return nil
+	}
+	pending, err := d.client.PendingNonceAt(ctx, d.account.Address)
+	if err != nil {
+		if d.synced {
+			// keep going with the cached nonce
+			utils.Logger().Warn().Err(err).Msg("[Faucet] cannot resync the nonce")
+			return nil
+		}
+		return errors.Wrap(err, "cannot get the nonce of the faucet account")
+	}
+	if !d.synced {
+		d.nonce = pending
+	} else if nonce := resyncedNonce(d.nonce, pending); nonce != d.nonce {
+		utils.Logger().Info().
+			Uint64("cached", d.nonce).
+			Uint64("pending", pending).
+			Msg("[Faucet] resynced the nonce")
+		d.nonce = nonce
+	}
+	d.synced, d.syncedAt = true, time.Now()
+	return nil
+}
+
+func (d *dispenser) send(to common.Address) (common.Hash, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+	if err := d.syncNonce(ctx); err != nil {
+		return common.Hash{}, err
+	}
+	tx := types.NewTransaction(d.nonce, to, d.shardID, d.amount, params.TxGas, d.gasPrice, nil)
+	tx, err := d.ks.SignTx(d.account, tx, d.chainID)
+	if err != nil {
+		return common.Hash{}, errors.Wrap(err, "cannot sign the transaction")
+	}
+	if err := d.client.SendTransaction(ctx, tx); err != nil {
+		// the node may have seen a different nonce; look it up again next time
+		d.synced = false
+		return common.Hash{}, errors.Wrap(err, "cannot submit the transaction")
+	}
+	d.nonce++
+	return tx.Hash(), nil
+}
+
+func main() {
+	listen := flag.String("listen", ":8000", "address the faucet HTTP server listens on")
+	rpcURL := flag.String("rpc", "http://localhost:9500", "RPC endpoint of a node in the faucet's shard")
+	keystoreDir := flag.String("keystore", ".hmy/keystore", "keystore directory holding the faucet account")
+	accountAddr := flag.String("account", "", "address of the faucet account (one1... or 0x...)")
+	passSrc := flag.String("pass", "", "passphrase source of the faucet account (pass:..., env:..., file:..., or stdin)")
+	chainID := flag.Uint64("chain_id", params.TestnetChainID.Uint64(), "chain ID of the network")
+	shardID := flag.Uint("shard_id", 0, "shard of the faucet account")
+	amount := flag.Float64("amount", 10, "amount in ONE sent per request")
+	gasPrice := flag.Uint64("gas_price", 1, "gas price in Gwei")
+	cooldown := flag.Duration("cooldown", 24*time.Hour, "minimum time between two grants to the same address or client IP")
+	recaptchaSecret := flag.String("recaptcha_secret", "", "source of the reCAPTCHA secret key (pass:..., env:..., file:...); no captcha is required if empty")
+	versionFlag := flag.Bool("version", false, "Output version info")
+	verbosity := flag.Int("verbosity", 3, "Logging verbosity: 0=silent, 1=error, 2=warn, 3=info, 4=debug, 5=detail (default: 3)")
+	flag.Parse()
+
+	if *versionFlag {
+		printVersion(os.Args[0])
+	}
+	utils.SetLogVerbosity(log.Lvl(*verbosity))
+
+	address, err := parseAddress(*accountAddr)
+	if err != nil {
+		utils.FatalErrMsg(err, "cannot parse faucet account %#v", *accountAddr)
+	}
+	ks := keystore.NewKeyStore(*keystoreDir, keystore.StandardScryptN, keystore.StandardScryptP)
+	account, err := ks.Find(accounts.Account{Address: address})
+	if err != nil {
+		utils.FatalErrMsg(err, "cannot find faucet account %s in %s", common2.MustAddressToBech32(address), *keystoreDir)
+	}
+	pass, err := utils.GetPassphraseFromSource(*passSrc)
+	if err != nil {
+		utils.FatalErrMsg(err, "cannot read passphrase")
+	}
+	if err := ks.Unlock(account, pass); err != nil {
+		utils.FatalErrMsg(err, "cannot unlock faucet account")
+	}
+	client, err := hmyclient.Dial(*rpcURL)
+	if err != nil {
+		utils.FatalErrMsg(err, "cannot connect to %s", *rpcURL)
+	}
+
+	amountWei := new(big.Int).Mul(big.NewInt(int64(*amount*denominations.Nano)), big.NewInt(denominations.Nano))
+	d := &dispenser{
+		client:   client,
+		ks:       ks,
+		account:  account,
+		chainID:  new(big.Int).SetUint64(*chainID),
+		shardID:  uint32(*shardID),
+		amount:   amountWei,
+		gasPrice: new(big.Int).Mul(new(big.Int).SetUint64(*gasPrice), big.NewInt(denominations.Nano)),
+	}
+
+	var verifier Verifier
+	if *recaptchaSecret != "" {
+		secret, err := utils.GetPassphraseFromSource(*recaptchaSecret)
+		if err != nil {
+			utils.FatalErrMsg(err, "cannot read reCAPTCHA secret key")
+		}
+		verifier = newRecaptcha(secret)
+	}
+
+	http.Handle("/fund", newFaucet(*cooldown, d.send, verifier))
+	utils.Logger().Info().
+		Str("listen", *listen).
+		Str("account", common2.MustAddressToBech32(address)).
+		Uint("shardID", *shardID).
+		Float64("amount", *amount).
+		Msg("[Faucet] starting")
+	if err := http.ListenAndServe(*listen, nil); err != nil {
+		utils.FatalErrMsg(err, "faucet HTTP server stopped")
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path"
+
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/harmony-one/harmony/core/export"
+	"github.com/harmony-one/harmony/internal/shardchain"
+)
+
+func printDumpUsage() {
+	fmt.Fprintf(os.Stderr, "Usage: %s dump blocks|state [flags]\n", os.Args[0])
+	fmt.Fprintln(os.Stderr, "Exports a range of blocks or states of a chain database, e.g. to diff two nodes.")
+	fmt.Fprintln(os.Stderr, "The node using the database must be stopped.")
+}
+
+// dumpMain runs the dump subcommand with the given arguments.
+func dumpMain(args []string) error {
+	if len(args) < 1 {
+		printDumpUsage()
+		os.Exit(2)
+	}
+	var dump func(io.Writer, ethdb.Database, uint64, uint64, export.Format) error
+	switch args[0] {
+	case "blocks":
+		dump = export.Blocks
+	case "state":
+		dump = export.States
+	default:
+		printDumpUsage()
+		os.Exit(2)
+	}
+
+	fs := flag.NewFlagSet("dump "+args[0], flag.ExitOnError)
+	dbDir := fs.String("db_dir", ".", "blockchain database directory")
+	shardID := fs.Uint("shard_id", 0, "the shard ID of the chain")
+	from := fs.Uint64("from", 0, "the first block number")
+	to := fs.Int64("to", -1, "the last block number; the head block if negative")
+	formatName := fs.String("format", "json", "the output format, json or csv")
+	output := fs.String("output", "", "the output file; standard output if empty")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	format, err := export.ParseFormat(*formatName)
+	if err != nil {
+		return err
+	}
+
+	// do not create an empty database where there is none
+	if _, err := os.Stat(path.Join(*dbDir, fmt.Sprintf("harmony_db_%d", *shardID))); err != nil {
+		return err
+	}
+	db, err := (&shardchain.LDBFactory{RootDir: *dbDir}).NewChainDB(uint32(*shardID))
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	last := uint64(*to)
+	if *to < 0 {
+		if last, err = export.HeadNumber(db); err != nil {
+			return err
+		}
+	}
+
+	w := os.Stdout
+	if *output != "" {
+		if w, err = os.Create(*output); err != nil {
+			return err
+		}
+		defer w.Close()
+	}
+	return dump(w, db, *from, last, format)
+}
@@ -23,6 +23,7 @@ import (
 	ethCommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/harmony-one/bls/ffi/go/bls"
+	"github.com/harmony-one/harmony/api/service/networkinfo"
 	"github.com/harmony-one/harmony/api/service/syncing"
 	"github.com/harmony-one/harmony/consensus"
 	"github.com/harmony-one/harmony/consensus/quorum"
@@ -40,6 +41,7 @@ import (
 	"github.com/harmony-one/harmony/multibls"
 	"github.com/harmony-one/harmony/node"
 	"github.com/harmony-one/harmony/p2p"
+	"github.com/harmony-one/harmony/p2p/host/chaos"
 	"github.com/harmony-one/harmony/p2p/p2pimpl"
 	p2putils "github.com/harmony-one/harmony/p2p/utils"
 	"github.com/harmony-one/harmony/shard"
@@ -76,9 +78,11 @@ var (
 	profile          = flag.Bool("profile", false, "Turn on profiling (CPU, Memory).")
 	metricsReportURL = flag.String("metrics_report_url", "", "If set, reports metrics to this URL.")
 	pprof            = flag.String("pprof", "", "what address and port the pprof profiling server should listen on")
+	pprofPort        = flag.Int("pprof_port", 0, "if not 0 and -pprof is not given, serve the pprof profiles on this port of localhost")
 	versionFlag      = flag.Bool("version", false, "Output version info")
+	printConfig      = flag.Bool("print_config", false, "Output the effective config, after applying the environment and config file, in the config file format")
 	onlyLogTps       = flag.Bool("only_log_tps", false, "Only log TPS if true")
-	dnsZone          = flag.String("dns_zone", "", "if given and not empty, use peers from the zone (default: use libp2p peer discovery instead)")
+	dnsZone          = flag.String("dns_zone", "", "if given and not empty, use peers from the zone; a comma-separated list of zones is tried in order (default: use libp2p peer discovery instead)")
 	dnsFlag          = flag.Bool("dns", true, "[deprecated] equivalent to -dns_zone t.hmny.io")
 	//Leader needs to have a minimal number of peers to start consensus
 	minPeers = flag.Int("min_peers", 32, "Minimal number of Peers in shard")
@@ -126,6 +130,9 @@ var (
 	initialAccounts = []*genesis.DeployAccount{}
 	// logging verbosity
 	verbosity = flag.Int("verbosity", 5, "Logging verbosity: 0=silent, 1=error, 2=warn, 3=info, 4=debug, 5=detail (default: 5)")
+	logFormat = flag.String("log_format", utils.LogFormatTerminal, "format of the console logs: terminal or json; log files are always json")
+	// per-package logging verbosity
+	logVmodule = flag.String("log_vmodule", "", "per-package logging verbosity overriding -verbosity, e.g. consensus=4,p2p/*=2")
 	// dbDir is the database directory.
 	dbDir = flag.String("db_dir", "", "blockchain database directory")
 	// Disable view change.
@@ -144,21 +151,47 @@ var (
 	webHookYamlPath = flag.String(
 		"webhook_yaml", "", "path for yaml config reporting double signing",
 	)
+	// Minimum gas price accepted into the tx pool
+	minGasPrice = flag.Uint("min_gas_price", uint(core.DefaultTxPoolConfig.PriceLimit), "minimum gas price (in atto) of transactions accepted into the tx pool")
+	// Connection to the bootnodes
+	bootnodeRetries        = flag.Int("bootnode_retries", networkinfo.DefaultConnectOptions().Retries, "number of attempts to connect to each bootnode")
+	bootnodeAttemptTimeout = flag.Duration("bootnode_attempt_timeout", networkinfo.DefaultConnectOptions().AttemptTimeout, "timeout of an attempt to connect to a bootnode")
+	bootnodeMaxBackoff     = flag.Duration("bootnode_max_backoff", networkinfo.DefaultConnectOptions().MaxWait, "maximum wait between attempts to connect to a bootnode")
+	// Genesis spec overriding the built-in genesis of the network type
+	genesisFile = flag.String("genesis_file", "", "path to a JSON genesis spec overriding the chain config and genesis of the network type")
+	// Status dashboard
+	dashboardAddr = flag.String("dashboard", "", "if given, serve the status dashboard of the node on this address, e.g. 127.0.0.1:6060")
+	// Fault injection into the incoming messages, for robustness tests
+	faultInjectionAddr = flag.String("fault_injection", "", "if given, inject network faults into the incoming messages, controlled over HTTP on this address (not on mainnet)")
 )
 
 func initSetup() {
 
 	// Setup pprof
-	if addr := *pprof; addr != "" {
-		go func() { http.ListenAndServe(addr, nil) }()
+	addr := *pprof
+	if addr == "" && *pprofPort != 0 {
+		addr = fmt.Sprintf("127.0.0.1:%d", *pprofPort)
+	}
+	if addr != "" {
+		go func() {
+			if err := http.ListenAndServe(addr, nil); err != nil {
+				utils.Logger().Error().Err(err).Str("addr", addr).Msg("pprof server failed")
+			}
+		}()
 	}
 
 	// maybe request passphrase for bls key.
 	passphraseForBls()
 
 	// Configure log parameters
+	if err := utils.SetLogFormat(*logFormat); err != nil {
+		utils.FatalErrMsg(err, "cannot set log format")
+	}
 	utils.SetLogContext(*port, *ip)
 	utils.SetLogVerbosity(log.Lvl(*verbosity))
+	if err := utils.SetLogVmodule(*logVmodule); err != nil {
+		utils.FatalErrMsg(err, "cannot set per-package logging verbosity")
+	}
 	utils.AddLogFile(fmt.Sprintf("%v/validator-%v-%v.log", *logFolder, *ip, *port), *logMaxSize)
 
 	if *onlyLogTps {
@@ -404,8 +437,22 @@ func createGlobalConfig() (*nodeconfig.ConfigType, error) {
 	if *logConn && nodeConfig.GetNetworkType() != nodeconfig.Mainnet {
 		myHost.GetP2PHost().Network().Notify(utils.NewConnLogger(utils.GetLogger()))
 	}
+	if addr := *faultInjectionAddr; addr != "" {
+		if nodeConfig.GetNetworkType() == nodeconfig.Mainnet {
+			return nil, errors.New("fault injection is not allowed on mainnet")
+		}
+		chaosHost := chaos.New(myHost)
+		go func() {
+			if err := http.ListenAndServe(addr, chaosHost); err != nil {
+				utils.Logger().Error().Err(err).Str("addr", addr).Msg("fault injection control server failed")
+			}
+		}()
+		myHost = chaosHost
+	}
 
 	nodeConfig.DBDir = *dbDir
+	nodeConfig.GenesisFile = *genesisFile
+	nodeConfig.MinGasPrice = uint64(*minGasPrice)
 
 	if p := *webHookYamlPath; p != "" {
 		config, err := webhooks.NewWebHooksFromPath(p)
@@ -479,7 +526,16 @@ func setupConsensusAndNode(nodeConfig *nodeconfig.ConfigType) *node.Node {
 		currentNode.SyncingPeerProvider = node.NewLocalSyncingPeerProvider(
 			6000, uint16(selfPort), epochConfig.NumShards(), uint32(epochConfig.NumNodesPerShard()))
 	case *dnsZone != "":
-		currentNode.SyncingPeerProvider = node.NewDNSSyncingPeerProvider(*dnsZone, syncing.GetSyncingPort(*port))
+		// Fail over between the given zones, then to the peers we discovered
+		providers := []node.SyncingPeerProvider{}
+		for _, zone := range strings.Split(*dnsZone, ",") {
+			if zone = strings.TrimSpace(zone); zone != "" {
+				providers = append(providers,
+					node.NewDNSSyncingPeerProvider(zone, syncing.GetSyncingPort(*port)))
+			}
+		}
+		providers = append(providers, node.NewLegacySyncingPeerProvider(currentNode))
+		currentNode.SyncingPeerProvider = node.NewFallbackSyncingPeerProvider(providers...)
 	case *dnsFlag:
 		currentNode.SyncingPeerProvider = node.NewDNSSyncingPeerProvider("t.hmny.io", syncing.GetSyncingPort(*port))
 	default:
@@ -525,6 +581,11 @@ func setupConsensusAndNode(nodeConfig *nodeconfig.ConfigType) *node.Node {
 
 	// Setup block period for currentNode.
 	currentNode.BlockPeriod = time.Duration(*blockPeriod) * time.Second
+	currentNode.BootnodeConnectOptions = networkinfo.ConnectOptions{
+		Retries:        *bootnodeRetries,
+		AttemptTimeout: *bootnodeAttemptTimeout,
+		MaxWait:        *bootnodeMaxBackoff,
+	}
 
 	// TODO: Disable drand. Currently drand isn't functioning but we want to compeletely turn it off for full protection.
 	// Enable it back after mainnet.
@@ -582,66 +643,18 @@ func setupBlacklist() (map[ethCommon.Address]struct{}, error) {
 	return addrMap, nil
 }
 
-func setupViperConfig() {
+func setupViperConfig() error {
 
 	// read from environment
 	envViper := viperconfig.CreateEnvViper()
 
 	//read from config file
-	configFileViper := viperconfig.CreateConfFileViper("./.hmy", "nodeconfig", "json")
-
-	viperconfig.ResetConfString(ip, envViper, configFileViper, "", "ip")
-	viperconfig.ResetConfString(port, envViper, configFileViper, "", "port")
-	viperconfig.ResetConfString(logFolder, envViper, configFileViper, "", "log_folder")
-	viperconfig.ResetConfInt(logMaxSize, envViper, configFileViper, "", "log_max_size")
-	viperconfig.ResetConfBool(freshDB, envViper, configFileViper, "", "fresh_db")
-	viperconfig.ResetConfBool(profile, envViper, configFileViper, "", "profile")
-	viperconfig.ResetConfString(metricsReportURL, envViper, configFileViper, "", "metrics_report_url")
-	viperconfig.ResetConfString(pprof, envViper, configFileViper, "", "pprof")
-
-	viperconfig.ResetConfBool(versionFlag, envViper, configFileViper, "", "version")
-	viperconfig.ResetConfBool(onlyLogTps, envViper, configFileViper, "", "only_log_tps")
-	viperconfig.ResetConfString(dnsZone, envViper, configFileViper, "", "dns_zone")
-	viperconfig.ResetConfBool(dnsFlag, envViper, configFileViper, "", "dns")
-	viperconfig.ResetConfInt(minPeers, envViper, configFileViper, "", "min_peers")
-	viperconfig.ResetConfString(keyFile, envViper, configFileViper, "", "key")
-	viperconfig.ResetConfBool(isGenesis, envViper, configFileViper, "", "is_genesis")
-	viperconfig.ResetConfBool(isArchival, envViper, configFileViper, "", "is_archival")
-	viperconfig.ResetConfString(delayCommit, envViper, configFileViper, "", "delay_commit")
-	viperconfig.ResetConfString(nodeType, envViper, configFileViper, "", "node_type")
-	viperconfig.ResetConfString(networkType, envViper, configFileViper, "", "network_type")
-
-	viperconfig.ResetConfInt(syncFreq, envViper, configFileViper, "", "sync_freq")
-	viperconfig.ResetConfInt(beaconSyncFreq, envViper, configFileViper, "", "beacon_sync_freq")
-	viperconfig.ResetConfInt(blockPeriod, envViper, configFileViper, "", "block_period")
-	viperconfig.ResetConfBool(leaderOverride, envViper, configFileViper, "", "leader_override")
-	viperconfig.ResetConfBool(stakingFlag, envViper, configFileViper, "", "staking")
-	viperconfig.ResetConfInt(shardID, envViper, configFileViper, "", "shard_id")
-	viperconfig.ResetConfBool(enableMemProfiling, envViper, configFileViper, "", "enableMemProfiling")
-	viperconfig.ResetConfBool(enableGC, envViper, configFileViper, "", "enableGC")
-	viperconfig.ResetConfString(blsKeyFile, envViper, configFileViper, "", "blskey_file")
-	viperconfig.ResetConfString(blsFolder, envViper, configFileViper, "", "blsfolder")
-	viperconfig.ResetConfString(blsPass, envViper, configFileViper, "", "blsPass")
-	viperconfig.ResetConfUInt(devnetNumShards, envViper, configFileViper, "", "dn_num_shards")
-	viperconfig.ResetConfInt(devnetShardSize, envViper, configFileViper, "", "dn_shard_size")
-	viperconfig.ResetConfInt(devnetHarmonySize, envViper, configFileViper, "", "dn_hmy_size")
-
-	viperconfig.ResetConfBool(logConn, envViper, configFileViper, "", "log_conn")
-	viperconfig.ResetConfString(keystoreDir, envViper, configFileViper, "", "keystore")
-	viperconfig.ResetConfBool(logP2P, envViper, configFileViper, "", "log_p2p")
-	viperconfig.ResetConfInt(verbosity, envViper, configFileViper, "", "verbosity")
-	viperconfig.ResetConfString(dbDir, envViper, configFileViper, "", "db_dir")
-	viperconfig.ResetConfBool(disableViewChange, envViper, configFileViper, "", "disable_view_change")
-	viperconfig.ResetConfBool(metricsFlag, envViper, configFileViper, "", "metrics")
-	viperconfig.ResetConfString(pushgatewayIP, envViper, configFileViper, "", "pushgateway_ip")
-	viperconfig.ResetConfString(pushgatewayPort, envViper, configFileViper, "", "pushgateway_port")
-	viperconfig.ResetConfBool(publicRPC, envViper, configFileViper, "", "public_rpc")
-	viperconfig.ResetConfInt(doRevertBefore, envViper, configFileViper, "", "do_revert_before")
-	viperconfig.ResetConfInt(revertTo, envViper, configFileViper, "", "revert_to")
-	viperconfig.ResetConfBool(revertBeacon, envViper, configFileViper, "", "revert_beacon")
-	viperconfig.ResetConfString(blacklistPath, envViper, configFileViper, "", "blacklist")
-	viperconfig.ResetConfString(webHookYamlPath, envViper, configFileViper, "", "webhook_yaml")
+	configFileViper, err := viperconfig.CreateConfFileViper("./.hmy", "nodeconfig", "json")
+	if err != nil {
+		return err
+	}
 
+	return viperconfig.ResetConfFlags(flag.CommandLine, envViper, configFileViper, "")
 }
 
 func main() {
@@ -650,9 +663,29 @@ func main() {
 	// build time.
 	os.Setenv("GODEBUG", "netdns=go")
 
+	if len(os.Args) > 1 && os.Args[1] == "dump" {
+		if err := dumpMain(os.Args[2:]); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "ERROR cannot dump: %s\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	flag.Var(&p2putils.BootNodes, "bootnodes", "a list of bootnode multiaddress (delimited by ,)")
 	flag.Parse()
 
+	if err := setupViperConfig(); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "ERROR cannot read config: %s\n", err)
+		os.Exit(1)
+	}
+	if *printConfig {
+		if err := viperconfig.PrintConfig(os.Stdout, flag.CommandLine, ""); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "ERROR cannot print config: %s\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	switch *nodeType {
 	case "validator":
 	case "explorer":
@@ -701,8 +734,21 @@ func main() {
 		_, _ = fmt.Fprintf(os.Stderr, "invalid network type: %#v\n", *networkType)
 		os.Exit(2)
 	}
-
-	setupViperConfig()
+	if *genesisFile != "" {
+		spec, err := core.ReadGenesisSpec(*genesisFile)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "ERROR cannot read genesis spec: %s\n", err)
+			os.Exit(1)
+		}
+		schedule, err := spec.ShardingSchedule()
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "ERROR invalid genesis sharding config: %s\n", err)
+			os.Exit(1)
+		}
+		if schedule != nil {
+			shard.Schedule = schedule
+		}
+	}
 
 	initSetup()
 
@@ -826,7 +872,10 @@ func main() {
 	}
 
 	go currentNode.SupportSyncing()
-	currentNode.ServiceManagerSetup()
+	if err := currentNode.ServiceManagerSetup(); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "ERROR cannot set up node services: %s\n", err)
+		os.Exit(1)
+	}
 
 	currentNode.RunServices()
 	// RPC for SDK not supported for mainnet.
@@ -836,6 +885,14 @@ func main() {
 			Msg("StartRPC failed")
 	}
 
+	if addr := *dashboardAddr; addr != "" {
+		go func() {
+			if err := http.ListenAndServe(addr, currentNode.DashboardHandler()); err != nil {
+				utils.Logger().Error().Err(err).Str("addr", addr).Msg("dashboard server failed")
+			}
+		}()
+	}
+
 	// Run additional node collectors
 	// Collect node metrics if metrics flag is set
 	if currentNode.NodeConfig.GetMetricsFlag() {
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// nodeSpec is a line of a localnet config file, in the format of
+// test/configs: "ip port mode account blspub".
+type nodeSpec struct {
+	IP      string
+	Port    string
+	Mode    string
+	Account string
+	BLSPub  string
+}
+
+// parseConfig returns the nodes of a localnet config, skipping empty lines.
+func parseConfig(r io.Reader) ([]nodeSpec, error) {
+	var nodes []nodeSpec
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 3 {
+			return nil, errors.Errorf("line %d: expecting ip, port and mode", line)
+		}
+		if _, err := strconv.ParseUint(fields[1], 10, 16); err != nil {
+			return nil, errors.Errorf("line %d: invalid port %#v", line, fields[1])
+		}
+		node := nodeSpec{IP: fields[0], Port: fields[1], Mode: fields[2]}
+		if len(fields) > 3 {
+			node.Account = fields[3]
+		}
+		if len(fields) > 4 {
+			node.BLSPub = fields[4]
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, scanner.Err()
+}
+
+// launched tells whether the node runs a harmony process; client lines only
+// reserve accounts.
+func (n *nodeSpec) launched() bool {
+	return n.Mode != "client"
+}
+
+// rpcURL returns the HTTP RPC endpoint of the node.
+func (n *nodeSpec) rpcURL() string {
+	port, _ := strconv.Atoi(n.Port)
+	return fmt.Sprintf("http://%s:%d", n.IP, port+rpcHTTPPortOffset)
+}
+
+// dbDir returns the chain database directory of the node.
+func (n *nodeSpec) dbDir() string {
+	return fmt.Sprintf("db-%s-%s", n.IP, n.Port)
+}
+
+// blsKeyFile returns the BLS key file of the node, or "" if it has no BLS
+// public key.
+func (n *nodeSpec) blsKeyFile() string {
+	if n.BLSPub == "" {
+		return ""
+	}
+	return filepath.Join(".hmy", n.BLSPub+".key")
+}
+
+// args returns the harmony arguments of the node, as test/deploy.sh builds
+// them, followed by the base and the extra arguments.
+func (n *nodeSpec) args(base, extra []string) []string {
+	args := append([]string(nil), base...)
+	args = append(args,
+		"-ip", n.IP, "-port", n.Port,
+		"-key", filepath.Join(os.TempDir(), fmt.Sprintf("%s-%s.key", n.IP, n.Port)),
+		"-db_dir", n.dbDir(),
+	)
+	if keyFile := n.blsKeyFile(); keyFile != "" {
+		args = append(args, "-blskey_file", keyFile)
+	}
+	if strings.HasSuffix(n.Mode, "archival") {
+		args = append(args, "-is_archival")
+	}
+	if strings.HasPrefix(n.Mode, "explorer") {
+		args = append(args, "-node_type=explorer", "-shard_id=0")
+	}
+	return append(args, extra...)
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
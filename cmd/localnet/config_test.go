@@ -0,0 +1,54 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	config := `127.0.0.1 9000 validator one1pdv9lrdwl0rg5vglh4xtyrv3wjk3wsqket7zxy 65f55eb3
+127.0.0.1 9001 explorer_archival
+
+127.0.0.1 9002 client one1m6m0ll3q7ljdqgmth2t5j7dfe6stykucpj2nr5
+`
+	nodes, err := parseConfig(strings.NewReader(config))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []nodeSpec{
+		{"127.0.0.1", "9000", "validator", "one1pdv9lrdwl0rg5vglh4xtyrv3wjk3wsqket7zxy", "65f55eb3"},
+		{"127.0.0.1", "9001", "explorer_archival", "", ""},
+		{"127.0.0.1", "9002", "client", "one1m6m0ll3q7ljdqgmth2t5j7dfe6stykucpj2nr5", ""},
+	}
+	if !reflect.DeepEqual(nodes, expected) {
+		t.Fatalf("got %+v", nodes)
+	}
+	if !nodes[0].launched() || nodes[2].launched() {
+		t.Error("wrong nodes launched")
+	}
+	if url := nodes[1].rpcURL(); url != "http://127.0.0.1:9501" {
+		t.Errorf("got RPC URL %s", url)
+	}
+
+	args := strings.Join(nodes[1].args([]string{"-dns=false"}, []string{"-verbosity", "3"}), " ")
+	for _, arg := range []string{"-dns=false ", "-port 9001", "-db_dir db-127.0.0.1-9001", "-is_archival", "-node_type=explorer", " -verbosity 3"} {
+		if !strings.Contains(args, arg) {
+			t.Errorf("%#v missing from %s", arg, args)
+		}
+	}
+
+	args = strings.Join(nodes[0].args(nil, nil), " ")
+	if !strings.Contains(args, "-blskey_file .hmy/65f55eb3.key") {
+		t.Errorf("BLS key file missing from %s", args)
+	}
+	if nodes[1].blsKeyFile() != "" {
+		t.Errorf("got BLS key file %s for a node without BLS key", nodes[1].blsKeyFile())
+	}
+
+	for _, config := range []string{"127.0.0.1 9000", "127.0.0.1 port validator"} {
+		if _, err := parseConfig(strings.NewReader(config)); err == nil {
+			t.Errorf("invalid config %#v accepted", config)
+		}
+	}
+}
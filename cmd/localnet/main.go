@@ -0,0 +1,297 @@
+// localnet runs a local network for development: a bootnode, the harmony
+// nodes listed in a config file of test/configs and optionally a txgen, as
+// subprocesses logging to a fresh log folder. It reports the height of every
+// node periodically and tears everything down on Ctrl-C.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/harmony-one/harmony/hmyclient"
+	"github.com/pkg/errors"
+)
+
+var (
+	version string
+	builtBy string
+	builtAt string
+	commit  string
+)
+
+const (
+	// same as in node/rpc.go
+	rpcHTTPPortOffset = 500
+
+	bootnodeTimeout = 10 * time.Second
+	rpcTimeout      = 2 * time.Second
+	stopTimeout     = 15 * time.Second
+)
+
+func printVersion(me string) {
+	fmt.Fprintf(os.Stderr, "Harmony (C) 2019. %v, version %v-%v (%v %v)\n", path.Base(me), version, commit, builtBy, builtAt)
+	os.Exit(0)
+}
+
+// process is a running subprocess of the local network
+type process struct {
+	name string
+	cmd  *exec.Cmd
+	done chan struct{}
+	err  error // valid once done is closed
+}
+
+// startProcess runs the binary with its output to the log file.
+func startProcess(name, logFile, binary string, args ...string) (*process, error) {
+	out, err := os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot create log file for %s", name)
+	}
+	cmd := exec.Command(binary, args...)
+	cmd.Stdout = out
+	cmd.Stderr = out
+	if err := cmd.Start(); err != nil {
+		out.Close()
+		return nil, errors.Wrapf(err, "cannot start %s", name)
+	}
+	p := &process{name: name, cmd: cmd, done: make(chan struct{})}
+	go func() {
+		p.err = cmd.Wait()
+		out.Close()
+		close(p.done)
+	}()
+	return p, nil
+}
+
+func (p *process) exited() bool {
+	select {
+	case <-p.done:
+		return true
+	default:
+		return false
+	}
+}
+
+var bootnodeAddrPattern = regexp.MustCompile(`BN_MA=(\S+)`)
+
+// waitForBootnodeAddr returns the multiaddress the bootnode prints at start.
+func waitForBootnodeAddr(bootnode *process, logFile string) (string, error) {
+	deadline := time.Now().Add(bootnodeTimeout)
+	for time.Now().Before(deadline) {
+		if data, err := ioutil.ReadFile(logFile); err == nil {
+			if match := bootnodeAddrPattern.FindSubmatch(data); match != nil {
+				return string(match[1]), nil
+			}
+		}
+		if bootnode.exited() {
+			return "", errors.Errorf("bootnode exited: %v; see %s", bootnode.err, logFile)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return "", errors.Errorf("bootnode address not found in %s", logFile)
+}
+
+// stopAll terminates the processes in order, then kills those still running
+// after the timeout.
+func stopAll(processes []*process) {
+	for _, p := range processes {
+		if !p.exited() {
+			_ = p.cmd.Process.Signal(syscall.SIGTERM)
+		}
+	}
+	timeout := time.After(stopTimeout)
+	for _, p := range processes {
+		select {
+		case <-p.done:
+		case <-timeout:
+			fmt.Printf("killing %s\n", p.name)
+			_ = p.cmd.Process.Kill()
+			<-p.done
+		}
+	}
+}
+
+// reportHealth prints the height or state of every node.
+func reportHealth(nodes []nodeSpec, processes []*process) {
+	var states []string
+	for i, node := range nodes {
+		p := processes[i]
+		state := ""
+		if p.exited() {
+			state = fmt.Sprintf("exited (%v)", p.err)
+		} else if client, err := hmyclient.Dial(node.rpcURL()); err != nil {
+			state = "rpc down"
+		} else {
+			ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+			height, err := client.BlockNumber(ctx)
+			cancel()
+			client.Close()
+			if err != nil {
+				state = "rpc down"
+			} else {
+				state = fmt.Sprintf("%d", height)
+			}
+		}
+		states = append(states, fmt.Sprintf("%s=%s", node.Port, state))
+	}
+	fmt.Printf("%s heights: %s\n", time.Now().Format("15:04:05"), strings.Join(states, " "))
+}
+
+func main() {
+	configFile := flag.String("config", "test/configs/local.txt", "the nodes to run, one \"ip port mode account blspub\" line each")
+	binDir := flag.String("bin", "bin", "the folder of the harmony, bootnode and txgen binaries")
+	networkType := flag.String("network_type", "localnet", "network type of the nodes")
+	minPeers := flag.Int("min_peers", 3, "minimal number of peers to start consensus")
+	bootnodePort := flag.Int("bootnode_port", 19876, "port of the bootnode")
+	withTxgen := flag.Bool("txgen", false, "also run a txgen")
+	txgenPort := flag.Int("txgen_port", 8000, "port of the txgen")
+	healthInterval := flag.Duration("health_interval", 10*time.Second, "how often to report the height of the nodes")
+	duration := flag.Duration("duration", 0, "if not 0, stop the network after this long")
+	keepDB := flag.Bool("keep_db", false, "keep the chain databases of the nodes after stopping")
+	versionFlag := flag.Bool("version", false, "Output version info")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] [extra harmony args]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *versionFlag {
+		printVersion(os.Args[0])
+	}
+
+	f, err := os.Open(*configFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cannot open config: %v\n", err)
+		os.Exit(1)
+	}
+	specs, err := parseConfig(f)
+	f.Close()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid config %s: %v\n", *configFile, err)
+		os.Exit(1)
+	}
+	var nodes []nodeSpec
+	for _, node := range specs {
+		if !node.launched() {
+			continue
+		}
+		// the key of a BLS public key in the genesis committee cannot be
+		// generated, it has to be provided
+		if keyFile := node.blsKeyFile(); keyFile != "" && !fileExists(keyFile) {
+			fmt.Fprintf(os.Stderr, "missing BLS key file %s of node %s:%s\n", keyFile, node.IP, node.Port)
+			os.Exit(1)
+		}
+		nodes = append(nodes, node)
+	}
+
+	logFolder := filepath.Join("tmp_log", "log-"+time.Now().Format("20060102-150405"))
+	if err := os.MkdirAll(logFolder, 0755); err != nil {
+		fmt.Fprintf(os.Stderr, "cannot create log folder: %v\n", err)
+		os.Exit(1)
+	}
+	// the nodes read the passphrase of their BLS keys from it
+	blsPassFile := filepath.Join(".hmy", "blspass.txt")
+	if !fileExists(blsPassFile) {
+		if err := os.MkdirAll(".hmy", 0755); err == nil {
+			err = ioutil.WriteFile(blsPassFile, nil, 0600)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "cannot create %s: %v\n", blsPassFile, err)
+			os.Exit(1)
+		}
+	}
+
+	var processes []*process
+	fail := func(err error) {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		stopAll(processes)
+		os.Exit(1)
+	}
+
+	bootnodeLog := filepath.Join(logFolder, "bootnode.log")
+	bootnode, err := startProcess("bootnode", bootnodeLog, filepath.Join(*binDir, "bootnode"),
+		"-port", fmt.Sprint(*bootnodePort), "-log_folder", logFolder)
+	if err != nil {
+		fail(err)
+	}
+	processes = append(processes, bootnode)
+	bootnodeAddr, err := waitForBootnodeAddr(bootnode, bootnodeLog)
+	if err != nil {
+		fail(err)
+	}
+	fmt.Printf("bootnode: %s\n", bootnodeAddr)
+
+	baseArgs := []string{
+		"-log_folder", logFolder, "-min_peers", fmt.Sprint(*minPeers),
+		"-bootnodes", bootnodeAddr, "-network_type=" + *networkType,
+		"-blspass", "file:" + blsPassFile, "-dns=false",
+	}
+	nodeProcesses := make([]*process, len(nodes))
+	for i := range nodes {
+		name := fmt.Sprintf("node %s:%s", nodes[i].IP, nodes[i].Port)
+		logFile := filepath.Join(logFolder, fmt.Sprintf("node-%s-%s.log", nodes[i].IP, nodes[i].Port))
+		p, err := startProcess(name, logFile, filepath.Join(*binDir, "harmony"), nodes[i].args(baseArgs, flag.Args())...)
+		if err != nil {
+			fail(err)
+		}
+		processes = append(processes, p)
+		nodeProcesses[i] = p
+	}
+	fmt.Printf("started %d nodes, logging to %s\n", len(nodes), logFolder)
+
+	if *withTxgen {
+		p, err := startProcess("txgen", filepath.Join(logFolder, "txgen-stdout.log"), filepath.Join(*binDir, "txgen"),
+			"-port", fmt.Sprint(*txgenPort), "-bootnodes", bootnodeAddr,
+			"-log_folder", logFolder, "-duration", "-1")
+		if err != nil {
+			fail(err)
+		}
+		// stopped first
+		processes = append([]*process{p}, processes...)
+		fmt.Println("started txgen")
+	}
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	var timeout <-chan time.Time
+	if *duration > 0 {
+		timeout = time.After(*duration)
+	}
+	ticker := time.NewTicker(*healthInterval)
+	defer ticker.Stop()
+loop:
+	for {
+		select {
+		case <-ticker.C:
+			reportHealth(nodes, nodeProcesses)
+		case sig := <-stop:
+			fmt.Printf("got %s, stopping the network...\n", sig)
+			break loop
+		case <-timeout:
+			fmt.Println("duration elapsed, stopping the network...")
+			break loop
+		}
+	}
+
+	stopAll(processes)
+	if !*keepDB {
+		for _, node := range nodes {
+			if err := os.RemoveAll(node.dbDir()); err != nil {
+				fmt.Fprintf(os.Stderr, "cannot remove %s: %v\n", node.dbDir(), err)
+			}
+		}
+	}
+	fmt.Printf("stopped; logs are in %s\n", logFolder)
+}
@@ -27,7 +27,7 @@ func (consensus *Consensus) validatorSanityChecks(msg *msg_pb.Message) bool {
 		return false
 	}
 
-	if !senderKey.IsEqual(consensus.LeaderPubKey) &&
+	if !senderKey.IsEqual(consensus.LeaderPubKey()) &&
 		consensus.current.Mode() == Normal && !consensus.ignoreViewIDCheck {
 		consensus.getLogger().Warn().Msgf(
 			"[%s] SenderKey not match leader PubKey",
@@ -80,7 +80,7 @@ func (consensus *Consensus) leaderSanityChecks(msg *msg_pb.Message) bool {
 
 func (consensus *Consensus) isRightBlockNumAndViewID(recvMsg *FBFTMessage,
 ) bool {
-	if recvMsg.ViewID != consensus.viewID || recvMsg.BlockNum != consensus.blockNum {
+	if recvMsg.ViewID != consensus.GetViewID() || recvMsg.BlockNum != consensus.blockNum {
 		consensus.getLogger().Debug().
 			Uint64("MsgViewID", recvMsg.ViewID).
 			Uint64("MsgBlockNum", recvMsg.BlockNum).
@@ -106,18 +106,18 @@ func (consensus *Consensus) onAnnounceSanityChecks(recvMsg *FBFTMessage) bool {
 				Uint64("recvMsg.BlockNum", recvMsg.BlockNum).
 				Uint64("recvMsg.ViewID", recvMsg.ViewID).
 				Str("recvMsgBlockHash", recvMsg.BlockHash.Hex()).
-				Str("LeaderKey", consensus.LeaderPubKey.SerializeToHexStr()).
+				Str("LeaderKey", consensus.LeaderPubKey().SerializeToHexStr()).
 				Msg("[OnAnnounce] Leader is malicious")
 			if consensus.current.Mode() == ViewChanging {
 				consensus.getLogger().Debug().Msg(
 					"[OnAnnounce] Already in ViewChanging mode, conflicing announce, doing noop",
 				)
 			} else {
-				consensus.startViewChange(consensus.viewID + 1)
+				consensus.startViewChange(consensus.GetViewID() + 1)
 			}
 		}
 		consensus.getLogger().Debug().
-			Str("leaderKey", consensus.LeaderPubKey.SerializeToHexStr()).
+			Str("leaderKey", consensus.LeaderPubKey().SerializeToHexStr()).
 			Msg("[OnAnnounce] Announce message received again")
 	}
 	return consensus.isRightBlockNumCheck(recvMsg)
@@ -241,9 +241,9 @@ func (consensus *Consensus) onViewChangeSanityCheck(recvMsg *FBFTMessage) bool {
 }
 
 func (consensus *Consensus) onNewViewSanityCheck(recvMsg *FBFTMessage) bool {
-	if recvMsg.ViewID <= consensus.viewID {
+	if recvMsg.ViewID <= consensus.GetViewID() {
 		consensus.getLogger().Warn().
-			Uint64("LastSuccessfulConsensusViewID", consensus.viewID).
+			Uint64("LastSuccessfulConsensusViewID", consensus.GetViewID()).
 			Uint64("MsgViewChangingID", recvMsg.ViewID).
 			Msg("[onNewView] ViewID should be larger than the viewID of the last successful consensus")
 		return false
@@ -34,6 +34,8 @@ type Consensus struct {
 	FBFTLog *FBFTLog
 	// phase: different phase of FBFT protocol: pre-prepare, prepare, commit, finish etc
 	phase FBFTPhase
+	// mutex for changing the phase, so that it can be read outside of consensus
+	phaseLock sync.Mutex
 	// current indicates what state a node is in
 	current State
 	// epoch: current epoch number
@@ -83,9 +85,11 @@ type Consensus struct {
 	PubKey *multibls.PublicKey
 	// TODO(audit): SelfAddresses doesn't have the ECDSA address for external validators. Don't use it that way.
 	SelfAddresses map[string]common.Address
-	// the publickey of leader
-	LeaderPubKey *bls.PublicKey
-	viewID       uint64
+	// the publickey of leader, guarded by leaderLock
+	leaderPubKey *bls.PublicKey
+	leaderLock   sync.RWMutex
+	// accessed atomically
+	viewID uint64
 	// Blockhash - 32 byte
 	blockHash [32]byte
 	// Block to run consensus on
@@ -185,7 +189,7 @@ func (consensus *Consensus) GetLeaderPrivateKey(leaderKey *bls.PublicKey) (*bls.
 
 // GetConsensusLeaderPrivateKey returns consensus leader private key if node is the leader
 func (consensus *Consensus) GetConsensusLeaderPrivateKey() (*bls.SecretKey, error) {
-	return consensus.GetLeaderPrivateKey(consensus.LeaderPubKey)
+	return consensus.GetLeaderPrivateKey(consensus.LeaderPubKey())
 }
 
 // TODO: put shardId into chain reader's chain config
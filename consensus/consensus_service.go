@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math/big"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -89,7 +90,7 @@ func (consensus *Consensus) GetNodeIDs() []libp2p_peer.ID {
 
 // GetViewID returns the consensus ID
 func (consensus *Consensus) GetViewID() uint64 {
-	return consensus.viewID
+	return atomic.LoadUint64(&consensus.viewID)
 }
 
 // UpdatePublicKeys updates the PublicKeys for
@@ -104,9 +105,9 @@ func (consensus *Consensus) UpdatePublicKeys(pubKeys []*bls.PublicKey) int64 {
 			Str("BLSPubKey", pubKeys[i].SerializeToHexStr()).
 			Msg("Member")
 	}
-	consensus.LeaderPubKey = pubKeys[0]
+	consensus.SetLeaderPubKey(pubKeys[0])
 	utils.Logger().Info().
-		Str("info", consensus.LeaderPubKey.SerializeToHexStr()).Msg("My Leader")
+		Str("info", consensus.LeaderPubKey().SerializeToHexStr()).Msg("My Leader")
 	consensus.pubKeyLock.Unlock()
 	// reset states after update public keys
 	consensus.ResetState()
@@ -208,7 +209,7 @@ func (consensus *Consensus) String() string {
 		consensus.PubKey.SerializeToHexStr(),
 		hex.EncodeToString(consensus.blockHeader),
 		consensus.blockNum,
-		consensus.viewID,
+		consensus.GetViewID(),
 		consensus.ShardID,
 		consensus.epoch,
 	)
@@ -277,8 +278,8 @@ func (consensus *Consensus) verifyViewChangeSenderKey(msg *msg_pb.Message) (*bls
 
 // SetViewID set the viewID to the height of the blockchain
 func (consensus *Consensus) SetViewID(height uint64) {
-	consensus.viewID = height
-	consensus.current.viewID = height
+	atomic.StoreUint64(&consensus.viewID, height)
+	consensus.current.SetViewID(height)
 }
 
 // SetMode sets the mode of consensus
@@ -291,6 +292,13 @@ func (consensus *Consensus) Mode() Mode {
 	return consensus.current.Mode()
 }
 
+// Phase returns the current FBFT phase of consensus
+func (consensus *Consensus) Phase() FBFTPhase {
+	consensus.phaseLock.Lock()
+	defer consensus.phaseLock.Unlock()
+	return consensus.phase
+}
+
 // RegisterPRndChannel registers the channel for receiving randomness preimage from DRG protocol
 func (consensus *Consensus) RegisterPRndChannel(pRndChannel chan []byte) {
 	consensus.PRndChannel = pRndChannel
@@ -308,23 +316,23 @@ func (consensus *Consensus) checkViewID(msg *FBFTMessage) error {
 		//in syncing mode, node accepts incoming messages without viewID/leaderKey checking
 		//so only set mode to normal when new node enters consensus and need checking viewID
 		consensus.current.SetMode(Normal)
-		consensus.viewID = msg.ViewID
+		atomic.StoreUint64(&consensus.viewID, msg.ViewID)
 		consensus.current.SetViewID(msg.ViewID)
-		consensus.LeaderPubKey = msg.SenderPubkey
+		consensus.SetLeaderPubKey(msg.SenderPubkey)
 		consensus.ignoreViewIDCheck = false
 		consensus.consensusTimeout[timeoutConsensus].Start()
 		utils.Logger().Debug().
-			Uint64("viewID", consensus.viewID).
-			Str("leaderKey", consensus.LeaderPubKey.SerializeToHexStr()[:20]).
+			Uint64("viewID", consensus.GetViewID()).
+			Str("leaderKey", consensus.LeaderPubKey().SerializeToHexStr()[:20]).
 			Msg("viewID and leaderKey override")
 		utils.Logger().Debug().
-			Uint64("viewID", consensus.viewID).
+			Uint64("viewID", consensus.GetViewID()).
 			Uint64("block", consensus.blockNum).
 			Msg("Start consensus timer")
 		return nil
-	} else if msg.ViewID > consensus.viewID {
+	} else if msg.ViewID > consensus.GetViewID() {
 		return consensus_engine.ErrViewIDNotMatch
-	} else if msg.ViewID < consensus.viewID {
+	} else if msg.ViewID < consensus.GetViewID() {
 		return errors.New("view ID belongs to the past")
 	}
 	return nil
@@ -382,7 +390,7 @@ func (consensus *Consensus) reportMetrics(block types.Block) {
 		txHashes = append(txHashes, hex.EncodeToString(txHash[:]))
 	}
 	metrics := map[string]interface{}{
-		"key":             hex.EncodeToString(consensus.LeaderPubKey.Serialize()),
+		"key":             hex.EncodeToString(consensus.LeaderPubKey().Serialize()),
 		"tps":             tps,
 		"txCount":         numOfTxs,
 		"nodeCount":       consensus.Decider.ParticipantsCount() + 1,
@@ -398,7 +406,7 @@ func (consensus *Consensus) getLogger() *zerolog.Logger {
 	logger := utils.Logger().With().
 		Uint64("myEpoch", consensus.epoch).
 		Uint64("myBlock", consensus.blockNum).
-		Uint64("myViewID", consensus.viewID).
+		Uint64("myViewID", consensus.GetViewID()).
 		Interface("phase", consensus.phase).
 		Str("mode", consensus.current.Mode().String()).
 		Logger()
@@ -546,7 +554,7 @@ func (consensus *Consensus) UpdateConsensusInformation() Mode {
 	}
 
 	// update public keys in the committee
-	oldLeader := consensus.LeaderPubKey
+	oldLeader := consensus.LeaderPubKey()
 	pubKeys, _ := committeeToSet.BLSPublicKeys()
 
 	consensus.getLogger().Info().
@@ -584,7 +592,7 @@ func (consensus *Consensus) UpdateConsensusInformation() Mode {
 			consensus.getLogger().Debug().
 				Str("leaderPubKey", leaderPubKey.SerializeToHexStr()).
 				Msg("[UpdateConsensusInformation] Most Recent LeaderPubKey Updated Based on BlockChain")
-			consensus.LeaderPubKey = leaderPubKey
+			consensus.SetLeaderPubKey(leaderPubKey)
 		}
 	}
 
@@ -596,11 +604,11 @@ func (consensus *Consensus) UpdateConsensusInformation() Mode {
 			}
 
 			// If the leader changed and I myself become the leader
-			if !consensus.LeaderPubKey.IsEqual(oldLeader) && consensus.IsLeader() {
+			if !consensus.LeaderPubKey().IsEqual(oldLeader) && consensus.IsLeader() {
 				go func() {
 					utils.Logger().Debug().
 						Str("myKey", consensus.PubKey.SerializeToHexStr()).
-						Uint64("viewID", consensus.viewID).
+						Uint64("viewID", consensus.GetViewID()).
 						Uint64("block", consensus.blockNum).
 						Msg("[UpdateConsensusInformation] I am the New Leader")
 					consensus.ReadySignal <- struct{}{}
@@ -613,11 +621,26 @@ func (consensus *Consensus) UpdateConsensusInformation() Mode {
 	return Listening
 }
 
+// LeaderPubKey returns the public key of the current leader.
+func (consensus *Consensus) LeaderPubKey() *bls.PublicKey {
+	consensus.leaderLock.RLock()
+	defer consensus.leaderLock.RUnlock()
+	return consensus.leaderPubKey
+}
+
+// SetLeaderPubKey sets the public key of the current leader.
+func (consensus *Consensus) SetLeaderPubKey(pubKey *bls.PublicKey) {
+	consensus.leaderLock.Lock()
+	defer consensus.leaderLock.Unlock()
+	consensus.leaderPubKey = pubKey
+}
+
 // IsLeader check if the node is a leader or not by comparing the public key of
 // the node with the leader public key
 func (consensus *Consensus) IsLeader() bool {
+	leaderPubKey := consensus.LeaderPubKey()
 	for _, key := range consensus.PubKey.PublicKey {
-		if key.IsEqual(consensus.LeaderPubKey) {
+		if key.IsEqual(leaderPubKey) {
 			return true
 		}
 	}
@@ -3,6 +3,7 @@ package consensus
 import (
 	"bytes"
 	"encoding/hex"
+	"sync/atomic"
 	"time"
 
 	protobuf "github.com/golang/protobuf/proto"
@@ -178,7 +179,7 @@ func (consensus *Consensus) finalizeCommits() {
 		Uint64("epochNum", block.Epoch().Uint64()).
 		Uint64("ViewId", block.Header().ViewID().Uint64()).
 		Str("blockHash", block.Hash().String()).
-		Int("index", consensus.Decider.IndexOf(consensus.LeaderPubKey)).
+		Int("index", consensus.Decider.IndexOf(consensus.LeaderPubKey())).
 		Int("numTxns", len(block.Transactions())).
 		Int("numStakingTxns", len(block.StakingTransactions())).
 		Msg("HOORAY!!!!!!! CONSENSUS REACHED!!!!!!!")
@@ -272,8 +273,8 @@ func (consensus *Consensus) tryCatchup() {
 		// TODO(Chao): Explain the reasoning for these code
 		consensus.blockHash = [32]byte{}
 		consensus.blockNum = consensus.blockNum + 1
-		consensus.viewID = msgs[0].ViewID + 1
-		consensus.LeaderPubKey = msgs[0].SenderPubkey
+		atomic.StoreUint64(&consensus.viewID, msgs[0].ViewID+1)
+		consensus.SetLeaderPubKey(msgs[0].SenderPubkey)
 
 		consensus.getLogger().Info().Msg("[TryCatchup] Adding block to chain")
 		consensus.OnConsensusDone(block, msgs[0].Payload)
@@ -332,7 +333,7 @@ func (consensus *Consensus) Start(
 		defer ticker.Stop()
 		consensus.consensusTimeout[timeoutBootstrap].Start()
 		consensus.getLogger().Debug().
-			Uint64("viewID", consensus.viewID).
+			Uint64("viewID", consensus.GetViewID()).
 			Uint64("blockNum", consensus.blockNum).
 			Msg("[ConsensusMainLoop] Start bootstrap timeout (only once)")
 
@@ -354,7 +355,7 @@ func (consensus *Consensus) Start(
 					}
 					if k != timeoutViewChange {
 						consensus.getLogger().Debug().Msg("[ConsensusMainLoop] Ops Consensus Timeout!!!")
-						consensus.startViewChange(consensus.viewID + 1)
+						consensus.startViewChange(consensus.GetViewID() + 1)
 						break
 					} else {
 						consensus.getLogger().Debug().Msg("[ConsensusMainLoop] Ops View Change Timeout!!!")
@@ -482,7 +483,7 @@ func (consensus *Consensus) Start(
 				func() {
 					consensus.mutex.Lock()
 					defer consensus.mutex.Unlock()
-					if viewID == consensus.viewID {
+					if viewID == consensus.GetViewID() {
 						consensus.finalizeCommits()
 					}
 				}()
@@ -527,7 +528,7 @@ func (consensus *Consensus) GenerateVrfAndProof(newBlock *types.Block, vrfBlockN
 
 // ValidateVrfAndProof validates a VRF/Proof from hash of previous block
 func (consensus *Consensus) ValidateVrfAndProof(headerObj *block.Header) bool {
-	vrfPk := vrf_bls.NewVRFVerifier(consensus.LeaderPubKey)
+	vrfPk := vrf_bls.NewVRFVerifier(consensus.LeaderPubKey())
 	var blockHash [32]byte
 	previousHeader := consensus.ChainReader.GetHeaderByNumber(
 		headerObj.Number().Uint64() - 1,
@@ -28,7 +28,7 @@ func (consensus *Consensus) constructViewChangeMessage(pubKey *bls.PublicKey, pr
 	vcMsg.SenderPubkey = pubKey.Serialize()
 
 	// next leader key already updated
-	vcMsg.LeaderPubkey = consensus.LeaderPubKey.Serialize()
+	vcMsg.LeaderPubkey = consensus.LeaderPubKey().Serialize()
 
 	preparedMsgs := consensus.FBFTLog.GetMessagesByTypeSeqHash(
 		msg_pb.MessageType_PREPARED, consensus.blockNum, consensus.blockHash,
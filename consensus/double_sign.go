@@ -93,7 +93,7 @@ func (consensus *Consensus) checkDoubleSign(recvMsg *FBFTMessage) bool {
 								Offender: *addr,
 							}
 							consensus.SlashChan <- proof
-						}(consensus.SelfAddresses[consensus.LeaderPubKey.SerializeToHexStr()])
+						}(consensus.SelfAddresses[consensus.LeaderPubKey().SerializeToHexStr()])
 						return true
 					}
 				}
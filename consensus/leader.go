@@ -63,7 +63,7 @@ func (consensus *Consensus) announce(block *types.Block) {
 			consensus.priKey.PrivateKey[i].SignHash(consensus.blockHash[:]),
 			common.BytesToHash(consensus.blockHash[:]),
 			consensus.blockNum,
-			consensus.viewID,
+			consensus.GetViewID(),
 		); err != nil {
 			return
 		}
@@ -105,7 +105,7 @@ func (consensus *Consensus) onPrepare(msg *msg_pb.Message) {
 		return
 	}
 
-	if recvMsg.ViewID != consensus.viewID || recvMsg.BlockNum != consensus.blockNum {
+	if recvMsg.ViewID != consensus.GetViewID() || recvMsg.BlockNum != consensus.blockNum {
 		consensus.getLogger().Debug().
 			Uint64("MsgViewID", recvMsg.ViewID).
 			Uint64("MsgBlockNum", recvMsg.BlockNum).
@@ -115,7 +115,7 @@ func (consensus *Consensus) onPrepare(msg *msg_pb.Message) {
 	}
 
 	if !consensus.FBFTLog.HasMatchingViewAnnounce(
-		consensus.blockNum, consensus.viewID, recvMsg.BlockHash,
+		consensus.blockNum, consensus.GetViewID(), recvMsg.BlockHash,
 	) {
 		consensus.getLogger().Debug().
 			Uint64("MsgViewID", recvMsg.ViewID).
@@ -262,7 +262,7 @@ func (consensus *Consensus) onCommit(msg *msg_pb.Message) {
 			time.Sleep(2 * time.Second)
 			logger.Debug().Msg("[OnCommit] Commit Grace Period Ended")
 			consensus.commitFinishChan <- viewID
-		}(consensus.viewID)
+		}(consensus.GetViewID())
 
 		consensus.msgSender.StopRetry(msg_pb.MessageType_PREPARED)
 	}
@@ -271,6 +271,6 @@ func (consensus *Consensus) onCommit(msg *msg_pb.Message) {
 		go func(viewID uint64) {
 			consensus.commitFinishChan <- viewID
 			logger.Info().Msg("[OnCommit] 90% Enough commits received")
-		}(consensus.viewID)
+		}(consensus.GetViewID())
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -28,6 +29,8 @@ type State struct {
 
 // Mode return the current node mode
 func (pm *State) Mode() Mode {
+	pm.mux.Lock()
+	defer pm.mux.Unlock()
 	return pm.mode
 }
 
@@ -40,6 +43,8 @@ func (pm *State) SetMode(s Mode) {
 
 // ViewID return the current viewchanging id
 func (pm *State) ViewID() uint64 {
+	pm.mux.Lock()
+	defer pm.mux.Unlock()
 	return pm.viewID
 }
 
@@ -52,11 +57,15 @@ func (pm *State) SetViewID(viewID uint64) {
 
 // GetViewID returns the current viewchange viewID
 func (pm *State) GetViewID() uint64 {
+	pm.mux.Lock()
+	defer pm.mux.Unlock()
 	return pm.viewID
 }
 
 // switchPhase will switch FBFTPhase to nextPhase if the desirePhase equals the nextPhase
 func (consensus *Consensus) switchPhase(desired FBFTPhase, override bool) {
+	consensus.phaseLock.Lock()
+	defer consensus.phaseLock.Unlock()
 	if override {
 		consensus.phase = desired
 		return
@@ -78,10 +87,10 @@ func (consensus *Consensus) switchPhase(desired FBFTPhase, override bool) {
 
 // GetNextLeaderKey uniquely determine who is the leader for given viewID
 func (consensus *Consensus) GetNextLeaderKey() *bls.PublicKey {
-	wasFound, next := consensus.Decider.NextAfter(consensus.LeaderPubKey)
+	wasFound, next := consensus.Decider.NextAfter(consensus.LeaderPubKey())
 	if !wasFound {
 		consensus.getLogger().Warn().
-			Str("key", consensus.LeaderPubKey.SerializeToHexStr()).
+			Str("key", consensus.LeaderPubKey().SerializeToHexStr()).
 			Msg("GetNextLeaderKey: currentLeaderKey not found")
 	}
 	return next
@@ -120,14 +129,14 @@ func (consensus *Consensus) startViewChange(viewID uint64) {
 	consensus.consensusTimeout[timeoutBootstrap].Stop()
 	consensus.current.SetMode(ViewChanging)
 	consensus.current.SetViewID(viewID)
-	consensus.LeaderPubKey = consensus.GetNextLeaderKey()
+	consensus.SetLeaderPubKey(consensus.GetNextLeaderKey())
 
-	diff := int64(viewID - consensus.viewID)
+	diff := int64(viewID - consensus.GetViewID())
 	duration := time.Duration(diff * diff * int64(viewChangeDuration))
 	consensus.getLogger().Info().
 		Uint64("ViewChangingID", viewID).
 		Dur("timeoutDuration", duration).
-		Str("NextLeader", consensus.LeaderPubKey.SerializeToHexStr()).
+		Str("NextLeader", consensus.LeaderPubKey().SerializeToHexStr()).
 		Msg("[startViewChange]")
 
 	for i, key := range consensus.PubKey.PublicKey {
@@ -327,7 +336,7 @@ func (consensus *Consensus) onViewChange(msg *msg_pb.Message) {
 	// received enough view change messages, change state to normal consensus
 	if consensus.Decider.IsQuorumAchievedByMask(consensus.viewIDBitmap[recvMsg.ViewID]) {
 		consensus.current.SetMode(Normal)
-		consensus.LeaderPubKey = newLeaderKey
+		consensus.SetLeaderPubKey(newLeaderKey)
 		consensus.ResetState()
 		if len(consensus.m1Payload) == 0 {
 			// TODO(Chao): explain why ReadySignal is sent only in this case but not the other case.
@@ -395,7 +404,7 @@ func (consensus *Consensus) onViewChange(msg *msg_pb.Message) {
 				Msg("could not send out the NEWVIEW message")
 		}
 
-		consensus.viewID = recvMsg.ViewID
+		atomic.StoreUint64(&consensus.viewID, recvMsg.ViewID)
 		consensus.ResetViewChangeState()
 		consensus.consensusTimeout[timeoutViewChange].Stop()
 		consensus.consensusTimeout[timeoutConsensus].Start()
@@ -404,7 +413,7 @@ func (consensus *Consensus) onViewChange(msg *msg_pb.Message) {
 			Msg("[onViewChange] New Leader Start Consensus Timer and Stop View Change Timer")
 		consensus.getLogger().Debug().
 			Str("myKey", consensus.PubKey.SerializeToHexStr()).
-			Uint64("viewID", consensus.viewID).
+			Uint64("viewID", consensus.GetViewID()).
 			Uint64("block", consensus.blockNum).
 			Msg("[onViewChange] I am the New Leader")
 	}
@@ -503,15 +512,15 @@ func (consensus *Consensus) onNewView(msg *msg_pb.Message) {
 	}
 
 	// newView message verified success, override my state
-	consensus.viewID = recvMsg.ViewID
+	atomic.StoreUint64(&consensus.viewID, recvMsg.ViewID)
 	consensus.current.SetViewID(recvMsg.ViewID)
-	consensus.LeaderPubKey = senderKey
+	consensus.SetLeaderPubKey(senderKey)
 	consensus.ResetViewChangeState()
 
 	// change view and leaderKey to keep in sync with network
 	if consensus.blockNum != recvMsg.BlockNum {
 		consensus.getLogger().Debug().
-			Str("newLeaderKey", consensus.LeaderPubKey.SerializeToHexStr()).
+			Str("newLeaderKey", consensus.LeaderPubKey().SerializeToHexStr()).
 			Uint64("MsgBlockNum", recvMsg.BlockNum).
 			Msg("[onNewView] New Leader Changed")
 		return
@@ -552,7 +561,7 @@ func (consensus *Consensus) onNewView(msg *msg_pb.Message) {
 		consensus.getLogger().Info().Msg("onNewView === announce")
 	}
 	consensus.getLogger().Debug().
-		Str("newLeaderKey", consensus.LeaderPubKey.SerializeToHexStr()).
+		Str("newLeaderKey", consensus.LeaderPubKey().SerializeToHexStr()).
 		Msg("new leader changed")
 	consensus.getLogger().Debug().
 		Msg("validator start consensus timer and stop view change timer")
@@ -0,0 +1,221 @@
+// Package export writes ranges of blocks and states of a chain database in
+// text formats, so that the chains of two nodes can be diffed.
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/pkg/errors"
+
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/types"
+)
+
+// Format is an output format.
+type Format string
+
+// Output formats
+const (
+	JSON Format = "json" // one JSON object per line
+	CSV  Format = "csv"  // comma-separated values with a header row
+)
+
+// ParseFormat returns the output format of the given name.
+func ParseFormat(name string) (Format, error) {
+	switch format := Format(name); format {
+	case JSON, CSV:
+		return format, nil
+	}
+	return "", errors.Errorf("unknown format %#v", name)
+}
+
+// HeadNumber returns the number of the head block of the chain in db.
+func HeadNumber(db ethdb.Database) (uint64, error) {
+	hash := rawdb.ReadHeadBlockHash(db)
+	number := rawdb.ReadHeaderNumber(db, hash)
+	if number == nil {
+		return 0, errors.New("no head block")
+	}
+	return *number, nil
+}
+
+// canonicalBlock returns the canonical block of the given number.
+func canonicalBlock(db ethdb.Database, number uint64) (*types.Block, error) {
+	block := rawdb.ReadBlock(db, rawdb.ReadCanonicalHash(db, number), number)
+	if block == nil {
+		return nil, errors.Errorf("no canonical block %d", number)
+	}
+	return block, nil
+}
+
+var blockColumns = []string{
+	"number", "hash", "parentHash", "shardID", "epoch", "viewID", "timestamp",
+	"stateRoot", "transactionsRoot", "receiptsRoot", "gasLimit", "gasUsed",
+	"numTransactions", "numStakingTransactions",
+}
+
+type blockRecord struct {
+	Number                 uint64 `json:"number"`
+	Hash                   string `json:"hash"`
+	ParentHash             string `json:"parentHash"`
+	ShardID                uint32 `json:"shardID"`
+	Epoch                  uint64 `json:"epoch"`
+	ViewID                 uint64 `json:"viewID"`
+	Timestamp              uint64 `json:"timestamp"`
+	StateRoot              string `json:"stateRoot"`
+	TransactionsRoot       string `json:"transactionsRoot"`
+	ReceiptsRoot           string `json:"receiptsRoot"`
+	GasLimit               uint64 `json:"gasLimit"`
+	GasUsed                uint64 `json:"gasUsed"`
+	NumTransactions        int    `json:"numTransactions"`
+	NumStakingTransactions int    `json:"numStakingTransactions"`
+}
+
+func newBlockRecord(block *types.Block) *blockRecord {
+	return &blockRecord{
+		Number:                 block.NumberU64(),
+		Hash:                   block.Hash().Hex(),
+		ParentHash:             block.ParentHash().Hex(),
+		ShardID:                block.ShardID(),
+		Epoch:                  block.Epoch().Uint64(),
+		ViewID:                 block.Header().ViewID().Uint64(),
+		Timestamp:              block.Time().Uint64(),
+		StateRoot:              block.Root().Hex(),
+		TransactionsRoot:       block.TxHash().Hex(),
+		ReceiptsRoot:           block.ReceiptHash().Hex(),
+		GasLimit:               block.GasLimit(),
+		GasUsed:                block.GasUsed(),
+		NumTransactions:        len(block.Transactions()),
+		NumStakingTransactions: len(block.StakingTransactions()),
+	}
+}
+
+func (r *blockRecord) row() []string {
+	return []string{
+		strconv.FormatUint(r.Number, 10), r.Hash, r.ParentHash,
+		strconv.FormatUint(uint64(r.ShardID), 10),
+		strconv.FormatUint(r.Epoch, 10), strconv.FormatUint(r.ViewID, 10),
+		strconv.FormatUint(r.Timestamp, 10),
+		r.StateRoot, r.TransactionsRoot, r.ReceiptsRoot,
+		strconv.FormatUint(r.GasLimit, 10), strconv.FormatUint(r.GasUsed, 10),
+		strconv.Itoa(r.NumTransactions), strconv.Itoa(r.NumStakingTransactions),
+	}
+}
+
+// Blocks writes the headers and transaction counts of the canonical blocks
+// numbered from through to of the chain in db.
+func Blocks(w io.Writer, db ethdb.Database, from, to uint64, format Format) error {
+	out := newWriter(w, format, blockColumns)
+	for number := from; number <= to; number++ {
+		block, err := canonicalBlock(db, number)
+		if err != nil {
+			return err
+		}
+		record := newBlockRecord(block)
+		if err := out.write(record, record.row()); err != nil {
+			return err
+		}
+	}
+	return out.flush()
+}
+
+var stateColumns = []string{
+	"number", "address", "balance", "nonce", "storageRoot", "codeHash",
+}
+
+type stateRecord struct {
+	Number uint64 `json:"number"`
+	state.Dump
+}
+
+// States writes the accounts in the states after the canonical blocks
+// numbered from through to of the chain in db.  JSON output includes the
+// storage of the accounts, while CSV output has only their storage roots.
+//
+// The states of blocks that are not the latest ones are kept only in
+// archival databases.
+func States(w io.Writer, db ethdb.Database, from, to uint64, format Format) error {
+	out := newWriter(w, format, stateColumns)
+	stateDB := state.NewDatabase(db)
+	for number := from; number <= to; number++ {
+		block, err := canonicalBlock(db, number)
+		if err != nil {
+			return err
+		}
+		s, err := state.New(block.Root(), stateDB)
+		if err != nil {
+			return errors.Wrapf(err, "cannot read state of block %d", number)
+		}
+		record := &stateRecord{Number: number, Dump: s.RawDump()}
+		addresses := make([]string, 0, len(record.Accounts))
+		for address := range record.Accounts {
+			addresses = append(addresses, address)
+		}
+		sort.Strings(addresses)
+		var rows [][]string
+		for _, address := range addresses {
+			account := record.Accounts[address]
+			rows = append(rows, []string{
+				strconv.FormatUint(number, 10), address, account.Balance,
+				strconv.FormatUint(account.Nonce, 10), account.Root, account.CodeHash,
+			})
+		}
+		if err := out.write(record, rows...); err != nil {
+			return err
+		}
+	}
+	return out.flush()
+}
+
+// writer writes records as JSON objects or CSV rows.
+type writer struct {
+	w       io.Writer
+	format  Format
+	csv     *csv.Writer
+	columns []string
+}
+
+func newWriter(w io.Writer, format Format, columns []string) *writer {
+	out := &writer{w: w, format: format, columns: columns}
+	if format == CSV {
+		out.csv = csv.NewWriter(w)
+	}
+	return out
+}
+
+// write writes the record, which is given in CSV as the rows.
+func (out *writer) write(record interface{}, rows ...[]string) error {
+	switch out.format {
+	case JSON:
+		data, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(out.w, "%s\n", data)
+		return err
+	case CSV:
+		if out.columns != nil {
+			if err := out.csv.Write(out.columns); err != nil {
+				return err
+			}
+			out.columns = nil
+		}
+		return out.csv.WriteAll(rows)
+	}
+	return errors.Errorf("unknown format %#v", out.format)
+}
+
+func (out *writer) flush() error {
+	if out.csv == nil {
+		return nil
+	}
+	out.csv.Flush()
+	return out.csv.Error()
+}
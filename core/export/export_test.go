@@ -0,0 +1,100 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/core"
+	common2 "github.com/harmony-one/harmony/internal/common"
+	"github.com/harmony-one/harmony/internal/params"
+)
+
+var testAddress = common.HexToAddress("0xb7a2c103728b7305b5ae6e961c94ee99c9fe8e2b")
+
+func newTestDB() (ethdb.Database, string) {
+	db := ethdb.NewMemDatabase()
+	gspec := core.Genesis{
+		Config:  params.TestChainConfig,
+		Factory: blockfactory.ForTest,
+		Alloc:   core.GenesisAlloc{testAddress: {Balance: big.NewInt(100)}},
+		ShardID: 1,
+	}
+	genesis := gspec.MustCommit(db)
+	return db, genesis.Hash().Hex()
+}
+
+func TestBlocks(t *testing.T) {
+	db, hash := newTestDB()
+
+	var buf bytes.Buffer
+	if err := Blocks(&buf, db, 0, 0, JSON); err != nil {
+		t.Fatalf("Blocks() failed: %s", err)
+	}
+	var record blockRecord
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatal(err)
+	}
+	if record.Hash != hash || record.ShardID != 1 {
+		t.Errorf("unexpected record %+v", record)
+	}
+
+	buf.Reset()
+	if err := Blocks(&buf, db, 0, 0, CSV); err != nil {
+		t.Fatalf("Blocks() failed: %s", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || rows[1][0] != "0" || rows[1][1] != hash {
+		t.Errorf("unexpected rows %v", rows)
+	}
+
+	if err := Blocks(&buf, db, 0, 1, JSON); err == nil {
+		t.Error("expected error for missing block")
+	}
+}
+
+func TestStates(t *testing.T) {
+	db, _ := newTestDB()
+	address := common2.MustAddressToBech32(testAddress)
+
+	var buf bytes.Buffer
+	if err := States(&buf, db, 0, 0, JSON); err != nil {
+		t.Fatalf("States() failed: %s", err)
+	}
+	var record stateRecord
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatal(err)
+	}
+	if account, ok := record.Accounts[address]; !ok || account.Balance != "100" {
+		t.Errorf("unexpected accounts %v", record.Accounts)
+	}
+
+	buf.Reset()
+	if err := States(&buf, db, 0, 0, CSV); err != nil {
+		t.Fatalf("States() failed: %s", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || rows[1][1] != address || rows[1][2] != "100" {
+		t.Errorf("unexpected rows %v", rows)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if format, err := ParseFormat("csv"); err != nil || format != CSV {
+		t.Errorf("ParseFormat(\"csv\") = %v, %v", format, err)
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
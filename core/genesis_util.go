@@ -6,8 +6,17 @@ import (
 	"io/ioutil"
 	"math/big"
 	"sort"
+	"strconv"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/rlp"
+	common2 "github.com/harmony-one/harmony/internal/common"
+	shardingconfig "github.com/harmony-one/harmony/internal/configs/sharding"
+	"github.com/harmony-one/harmony/internal/genesis"
+	"github.com/harmony-one/harmony/internal/params"
+	"github.com/harmony-one/harmony/numeric"
+	"github.com/pkg/errors"
 )
 
 // GenesisItem represents one genesis block transaction
@@ -69,3 +78,114 @@ func EncodeGenesisConfig(fileName string) string {
 	by := encodeGenesisConfig(gi)
 	return string(by)
 }
+
+// GenesisValidator is a member of an initial committee in a genesis spec.
+type GenesisValidator struct {
+	Address      string `json:"address"`
+	BlsPublicKey string `json:"blsPublicKey"`
+}
+
+// GenesisSpec is a genesis spec of a network, read from a JSON file.
+type GenesisSpec struct {
+	Config     *params.ChainConfig           `json:"config"`
+	ShardCount uint32                        `json:"shardCount"`
+	Committee  map[uint32][]GenesisValidator `json:"committee"` // by shard ID
+	GasLimit   math.HexOrDecimal64           `json:"gasLimit"`
+	Timestamp  math.HexOrDecimal64           `json:"timestamp"`
+	ExtraData  hexutil.Bytes                 `json:"extraData"`
+	Alloc      map[uint32]GenesisAlloc       `json:"alloc"` // by shard ID
+}
+
+// ReadGenesisSpec reads a genesis spec from a JSON file.
+// The spec must name a chain config with a chain ID, a gas limit, and the
+// allocations of each shard keyed by shard ID; shards not listed get none.
+// The shard count and the committees of all shards, which must be of the
+// same size, are optional; see ShardingSchedule.
+func ReadGenesisSpec(fileName string) (*GenesisSpec, error) {
+	input, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot read genesis spec %v", fileName)
+	}
+	spec := new(GenesisSpec)
+	if err := json.Unmarshal(input, spec); err != nil {
+		return nil, errors.Wrapf(err, "cannot parse genesis spec %v", fileName)
+	}
+	if err := spec.validate(); err != nil {
+		return nil, errors.Wrapf(err, "invalid genesis spec %v", fileName)
+	}
+	return spec, nil
+}
+
+func (spec *GenesisSpec) validate() error {
+	if spec.Config == nil || spec.Config.ChainID == nil {
+		return errors.New("no chain ID")
+	}
+	if spec.GasLimit == 0 {
+		return errors.New("no gas limit")
+	}
+	if spec.Alloc == nil {
+		return errors.New("no allocations")
+	}
+	if spec.ShardCount == 0 {
+		if spec.Committee != nil {
+			return errors.New("committee without shard count")
+		}
+		return nil
+	}
+	for shardID := range spec.Alloc {
+		if shardID >= spec.ShardCount {
+			return errors.Errorf("allocations to shard %d of %d", shardID, spec.ShardCount)
+		}
+	}
+	for shardID := range spec.Committee {
+		if shardID >= spec.ShardCount {
+			return errors.Errorf("committee of shard %d of %d", shardID, spec.ShardCount)
+		}
+	}
+	size := len(spec.Committee[0])
+	for shardID := uint32(0); shardID < spec.ShardCount; shardID++ {
+		members := spec.Committee[shardID]
+		if len(members) == 0 || len(members) != size {
+			return errors.Errorf(
+				"committee of shard %d has %d members instead of %d", shardID, len(members), size,
+			)
+		}
+		for _, member := range members {
+			if _, err := common2.Bech32ToAddress(member.Address); err != nil {
+				return errors.Wrapf(err, "invalid committee member address %#v", member.Address)
+			}
+		}
+	}
+	return nil
+}
+
+// ShardingSchedule returns a fixed sharding schedule with the shard count and
+// the committees of the spec, or nil if the spec has no shard count, in which
+// case the schedule of the network type applies.
+func (spec *GenesisSpec) ShardingSchedule() (shardingconfig.Schedule, error) {
+	if spec.ShardCount == 0 {
+		return nil, nil
+	}
+	numShards := int(spec.ShardCount)
+	size := len(spec.Committee[0])
+	// the genesis committee of shard i takes accounts i, i+numShards, ...
+	accounts := make([]genesis.DeployAccount, numShards*size)
+	for shardID, members := range spec.Committee {
+		for j, member := range members {
+			index := int(shardID) + j*numShards
+			accounts[index] = genesis.DeployAccount{
+				Index:        strconv.Itoa(index),
+				Address:      member.Address,
+				BlsPublicKey: member.BlsPublicKey,
+				ShardID:      shardID,
+			}
+		}
+	}
+	instance, err := shardingconfig.NewInstance(
+		spec.ShardCount, size, size, numeric.OneDec(), accounts, nil, nil, shardingconfig.VLBPE,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return shardingconfig.NewFixedSchedule(instance), nil
+}
@@ -1,8 +1,13 @@
 package core
 
 import (
+	"io/ioutil"
+	"math/big"
+	"os"
 	"strings"
 	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
 )
 
 func TestEncodeGenesisConfig(t *testing.T) {
@@ -17,3 +22,144 @@ func TestEncodeGenesisConfig(t *testing.T) {
 		}
 	}
 }
+
+var testValidators = []string{
+	`{"address": "one1pdv9lrdwl0rg5vglh4xtyrv3wjk3wsqket7zxy", "blsPublicKey": "65f55eb3052f9e9f632b2923be594ba77c55543f5c58ee1454b9cfd658d25e06373b0f7d42a19c84768139ea294f6204"}`,
+	`{"address": "one1m6m0ll3q7ljdqgmth2t5j7dfe6stykucpj2nr5", "blsPublicKey": "40379eed79ed82bebfb4310894fd33b6a3f8413a78dc4d43b98d0adc9ef69f3285df05eaab9f2ce5f7227f8cb920e809"}`,
+	`{"address": "one12fuf7x9rgtdgqg7vgq0962c556m3p7afsxgvll", "blsPublicKey": "02c8ff0b88f313717bc3a627d2f8bb172ba3ad3bb9ba3ecb8eed4b7c878653d3d4faf769876c528b73f343967f74a917"}`,
+	`{"address": "one16qsd5ant9v94jrs89mruzx62h7ekcfxmduh2rx", "blsPublicKey": "ee2474f93cba9241562efc7475ac2721ab0899edf8f7f115a656c0c1f9ef8203add678064878d174bb478fa2e6630502"}`,
+}
+
+func readTestGenesisSpec(t *testing.T, spec string) (*GenesisSpec, error) {
+	f, err := ioutil.TempFile("", "genesis")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString(spec); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	return ReadGenesisSpec(f.Name())
+}
+
+func TestReadGenesisSpec(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr bool
+	}{
+		{
+			name: "Valid",
+			spec: `{
+				"config": {"chain-id": 1234},
+				"gasLimit": "0x4c4b400",
+				"alloc": {
+					"1": {"b7a2c103728b7305b5ae6e961c94ee99c9fe8e2b": {"balance": "0x64"}}
+				}
+			}`,
+		},
+		{
+			name:    "NoChainID",
+			spec:    `{"config": {}, "gasLimit": "0x1", "alloc": {}}`,
+			wantErr: true,
+		},
+		{
+			name:    "NoGasLimit",
+			spec:    `{"config": {"chain-id": 1234}, "alloc": {}}`,
+			wantErr: true,
+		},
+		{
+			name:    "NoAlloc",
+			spec:    `{"config": {"chain-id": 1234}, "gasLimit": "0x1"}`,
+			wantErr: true,
+		},
+		{
+			name: "CommitteeWithoutShardCount",
+			spec: `{"config": {"chain-id": 1234}, "gasLimit": "0x1", "alloc": {},
+				"committee": {"0": [` + testValidators[0] + `]}}`,
+			wantErr: true,
+		},
+		{
+			name: "UnevenCommittees",
+			spec: `{"config": {"chain-id": 1234}, "gasLimit": "0x1", "alloc": {}, "shardCount": 2,
+				"committee": {"0": [` + testValidators[0] + `, ` + testValidators[2] + `], "1": [` + testValidators[1] + `]}}`,
+			wantErr: true,
+		},
+		{
+			name: "AllocBeyondShardCount",
+			spec: `{"config": {"chain-id": 1234}, "gasLimit": "0x1", "shardCount": 1,
+				"committee": {"0": [` + testValidators[0] + `]}, "alloc": {"1": {}}}`,
+			wantErr: true,
+		},
+		{
+			name:    "Malformed",
+			spec:    `{"config":`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := readTestGenesisSpec(t, tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadGenesisSpec() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if spec.Config.ChainID.Cmp(big.NewInt(1234)) != 0 {
+				t.Errorf("unexpected chain ID %v", spec.Config.ChainID)
+			}
+			if spec.GasLimit != 80000000 {
+				t.Errorf("unexpected gas limit %v", spec.GasLimit)
+			}
+			addr := common.HexToAddress("0xb7a2c103728b7305b5ae6e961c94ee99c9fe8e2b")
+			if acc, ok := spec.Alloc[1][addr]; !ok || acc.Balance.Cmp(big.NewInt(100)) != 0 {
+				t.Errorf("unexpected allocation %v", spec.Alloc)
+			}
+			if len(spec.Alloc[0]) != 0 {
+				t.Errorf("unexpected allocation in shard 0: %v", spec.Alloc[0])
+			}
+		})
+	}
+}
+
+func TestGenesisSpecShardingSchedule(t *testing.T) {
+	spec, err := readTestGenesisSpec(t, `{
+		"config": {"chain-id": 1234},
+		"gasLimit": "0x1",
+		"alloc": {},
+		"shardCount": 2,
+		"committee": {
+			"0": [`+testValidators[0]+`, `+testValidators[1]+`],
+			"1": [`+testValidators[2]+`, `+testValidators[3]+`]
+		}
+	}`)
+	if err != nil {
+		t.Fatalf("ReadGenesisSpec() failed: %s", err)
+	}
+	schedule, err := spec.ShardingSchedule()
+	if err != nil {
+		t.Fatalf("ShardingSchedule() failed: %s", err)
+	}
+	instance := schedule.InstanceForEpoch(big.NewInt(GenesisEpoch))
+	if instance.NumShards() != 2 || instance.NumNodesPerShard() != 2 {
+		t.Fatalf("unexpected %d shards of %d nodes", instance.NumShards(), instance.NumNodesPerShard())
+	}
+	// shard i takes accounts i, i+2
+	for i, want := range []string{
+		"one1pdv9lrdwl0rg5vglh4xtyrv3wjk3wsqket7zxy",
+		"one12fuf7x9rgtdgqg7vgq0962c556m3p7afsxgvll",
+		"one1m6m0ll3q7ljdqgmth2t5j7dfe6stykucpj2nr5",
+		"one16qsd5ant9v94jrs89mruzx62h7ekcfxmduh2rx",
+	} {
+		if got := instance.HmyAccounts()[i].Address; got != want {
+			t.Errorf("account %d is %s, want %s", i, got, want)
+		}
+	}
+
+	spec.ShardCount, spec.Committee = 0, nil
+	if schedule, err := spec.ShardingSchedule(); schedule != nil || err != nil {
+		t.Errorf("ShardingSchedule() = %v, %v without shard count", schedule, err)
+	}
+}
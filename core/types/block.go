@@ -204,6 +204,10 @@ type Block struct {
 	stakingTransactions staking.StakingTransactions
 	incomingReceipts    CXReceiptsProofs
 
+	// commitSigAndBitmap is the commit signature and bitmap that finalized
+	// this block; it is not part of the block and only set in transit.
+	commitSigAndBitmap []byte
+
 	// caches
 	hash atomic.Value
 	size atomic.Value
@@ -244,6 +248,18 @@ func (b *Block) SetLastCommitSig(sig []byte, signers []byte) {
 	b.header.SetLastCommitBitmap(signers)
 }
 
+// SetCurrentCommitSig sets the commit group signature and bitmap that
+// finalized this block.
+func (b *Block) SetCurrentCommitSig(sigAndBitmap []byte) {
+	b.commitSigAndBitmap = sigAndBitmap
+}
+
+// GetCurrentCommitSig returns the commit group signature and bitmap that
+// finalized this block, or nil if unknown.
+func (b *Block) GetCurrentCommitSig() []byte {
+	return b.commitSigAndBitmap
+}
+
 // DeprecatedTd is an old relic for extracting the TD of a block. It is in the
 // code solely to facilitate upgrading the database from the old format to the
 // new, after which it should be deleted. Do not use!
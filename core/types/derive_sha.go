@@ -19,8 +19,10 @@ package types
 import (
 	"bytes"
 	"encoding/binary"
+	"errors"
 
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/rlp"
@@ -42,6 +44,12 @@ type DerivableList interface {
 
 // DeriveSha calculates the hash of the trie generated by DerivableList.
 func DeriveSha(list ...DerivableBase) common.Hash {
+	return deriveTrie(list...).Hash()
+}
+
+// deriveTrie builds the trie DeriveSha hashes; items of all lists are keyed by
+// their RLP-encoded position in the concatenation of the lists.
+func deriveTrie(list ...DerivableBase) *trie.Trie {
 	keybuf := new(bytes.Buffer)
 	trie := new(trie.Trie)
 	var num uint
@@ -54,7 +62,53 @@ func DeriveSha(list ...DerivableBase) common.Hash {
 			num++
 		}
 	}
-	return trie.Hash()
+	return trie
+}
+
+// proofList collects the trie nodes of a Merkle proof in order
+type proofList [][]byte
+
+func (n *proofList) Put(key []byte, value []byte) error {
+	*n = append(*n, value)
+	return nil
+}
+
+// DeriveShaProof returns the Merkle proof that the item at position index of
+// the concatenated lists is included under the root returned by DeriveSha,
+// e.g. a transaction under the TxHash of its block header.
+func DeriveShaProof(index uint, list ...DerivableBase) ([][]byte, error) {
+	key, err := rlp.EncodeToBytes(index)
+	if err != nil {
+		return nil, err
+	}
+	var proof proofList
+	if err := deriveTrie(list...).Prove(key, 0, &proof); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// VerifyDeriveShaProof checks a proof produced by DeriveShaProof against root
+// and returns the RLP encoding of the proven item.
+func VerifyDeriveShaProof(root common.Hash, index uint, proof [][]byte) ([]byte, error) {
+	key, err := rlp.EncodeToBytes(index)
+	if err != nil {
+		return nil, err
+	}
+	proofDb := ethdb.NewMemDatabase()
+	for _, node := range proof {
+		if err := proofDb.Put(crypto.Keccak256(node), node); err != nil {
+			return nil, err
+		}
+	}
+	value, _, err := trie.VerifyProof(root, key, proofDb)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, errors.New("no item at the given index")
+	}
+	return value, nil
 }
 
 //// Legacy forked logic. Keep as is, but do not use it anymore ->
@@ -0,0 +1,47 @@
+package types
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestDeriveShaProof(t *testing.T) {
+	var txs Transactions
+	for i := uint64(0); i < 20; i++ {
+		txs = append(txs, NewTransaction(
+			i, common.BigToAddress(big.NewInt(int64(i))), 0,
+			big.NewInt(int64(i)), 21000, big.NewInt(1), nil,
+		))
+	}
+	root := DeriveSha(txs)
+	for i := 0; i < txs.Len(); i++ {
+		proof, err := DeriveShaProof(uint(i), txs)
+		if err != nil {
+			t.Fatalf("cannot prove tx %d: %v", i, err)
+		}
+		value, err := VerifyDeriveShaProof(root, uint(i), proof)
+		if err != nil {
+			t.Fatalf("cannot verify proof of tx %d: %v", i, err)
+		}
+		if !bytes.Equal(value, txs.GetRlp(i)) {
+			t.Errorf("proof of tx %d proves a different item", i)
+		}
+	}
+
+	proof, err := DeriveShaProof(3, txs)
+	if err != nil {
+		t.Fatalf("cannot prove tx: %v", err)
+	}
+	if _, err := VerifyDeriveShaProof(root, 4, proof); err == nil {
+		t.Error("proof verified for the wrong index")
+	}
+	if _, err := VerifyDeriveShaProof(common.Hash{1}, 3, proof); err == nil {
+		t.Error("proof verified against the wrong root")
+	}
+	if _, err := VerifyDeriveShaProof(root, 100, proof); err == nil {
+		t.Error("proof verified for a missing item")
+	}
+}
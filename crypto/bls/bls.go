@@ -38,6 +38,47 @@ func AggregateSig(sigs []*bls.Sign) *bls.Sign {
 	return &aggregatedSig
 }
 
+// BytesToBlsSecretKey converts bytes into bls.SecretKey pointer.
+func BytesToBlsSecretKey(bytes []byte) (*bls.SecretKey, error) {
+	if len(bytes) == 0 {
+		return nil, fmt.Errorf("[BytesToBlsSecretKey] bytes is empty")
+	}
+	priKey := &bls.SecretKey{}
+	err := priKey.Deserialize(bytes)
+	return priKey, err
+}
+
+// AggregatePublicKeys aggregates the BLS public keys into the single key
+// that verifies signatures aggregated with AggregateSig over one message.
+func AggregatePublicKeys(pubs []*bls.PublicKey) *bls.PublicKey {
+	var aggregatedPub bls.PublicKey
+	for _, pub := range pubs {
+		aggregatedPub.Add(pub)
+	}
+	return &aggregatedPub
+}
+
+// VerifyAggregateSig verifies an aggregated signature of the same message by
+// all the public keys. The keys must have proven possession of their secret
+// key (see VerifyProofOfPossession), or a rogue key can forge the signature.
+func VerifyAggregateSig(sig *bls.Sign, pubs []*bls.PublicKey, msg []byte) bool {
+	if len(pubs) == 0 {
+		return false
+	}
+	return sig.VerifyHash(AggregatePublicKeys(pubs), msg)
+}
+
+// ProofOfPossession returns the proof that the holder of the public key knows
+// the secret key, i.e. a signature of the public key itself.
+func ProofOfPossession(priKey *bls.SecretKey) *bls.Sign {
+	return priKey.GetPop()
+}
+
+// VerifyProofOfPossession verifies a proof returned by ProofOfPossession.
+func VerifyProofOfPossession(pubKey *bls.PublicKey, pop *bls.Sign) bool {
+	return pop.VerifyPop(pubKey)
+}
+
 // Mask represents a cosigning participation bitmask.
 type Mask struct {
 	Bitmap          []byte
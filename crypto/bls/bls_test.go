@@ -142,6 +142,57 @@ func TestAggregatedSignature(test *testing.T) {
 	}
 }
 
+func TestVerifyAggregateSig(test *testing.T) {
+	msg := []byte("message")
+	var pubs []*bls.PublicKey
+	var sigs []*bls.Sign
+	for i := 0; i < 3; i++ {
+		sec := RandPrivateKey()
+		pubs = append(pubs, sec.GetPublicKey())
+		sigs = append(sigs, sec.SignHash(msg))
+	}
+	sig := AggregateSig(sigs)
+
+	if !VerifyAggregateSig(sig, pubs, msg) {
+		test.Error("valid aggregated signature not verified")
+	}
+	if VerifyAggregateSig(sig, pubs, []byte("other message")) {
+		test.Error("aggregated signature verified for another message")
+	}
+	if VerifyAggregateSig(sig, pubs[:2], msg) {
+		test.Error("aggregated signature verified without one of the signers")
+	}
+	if VerifyAggregateSig(sig, nil, msg) {
+		test.Error("aggregated signature verified without signers")
+	}
+}
+
+func TestProofOfPossession(test *testing.T) {
+	sec, other := RandPrivateKey(), RandPrivateKey()
+	pop := ProofOfPossession(sec)
+
+	if !VerifyProofOfPossession(sec.GetPublicKey(), pop) {
+		test.Error("valid proof of possession not verified")
+	}
+	if VerifyProofOfPossession(other.GetPublicKey(), pop) {
+		test.Error("proof of possession verified for another key")
+	}
+}
+
+func TestBytesToBlsSecretKey(test *testing.T) {
+	sec := RandPrivateKey()
+	decoded, err := BytesToBlsSecretKey(sec.Serialize())
+	if err != nil {
+		test.Fatal(err)
+	}
+	if !decoded.IsEqual(sec) {
+		test.Error("decoded secret key differs")
+	}
+	if _, err := BytesToBlsSecretKey(nil); err == nil {
+		test.Error("empty bytes decoded")
+	}
+}
+
 func TestAggregateMasks(test *testing.T) {
 	message := []byte("message")
 	newMessage := []byte("message")
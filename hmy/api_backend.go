@@ -268,12 +268,18 @@ func (b *APIBackend) GetShardID() uint32 {
 
 // GetValidators returns validators for a particular epoch.
 func (b *APIBackend) GetValidators(epoch *big.Int) (*shard.Committee, error) {
+	return b.GetCommittee(epoch, b.GetShardID())
+}
+
+// GetCommittee returns the committee of the given shard for a particular
+// epoch, or nil if there is none.
+func (b *APIBackend) GetCommittee(epoch *big.Int, shardID uint32) (*shard.Committee, error) {
 	state, err := b.hmy.BlockChain().ReadShardState(epoch)
 	if err != nil {
 		return nil, err
 	}
 	for _, committee := range state.Shards {
-		if committee.ShardID == b.GetShardID() {
+		if committee.ShardID == shardID {
 			return &committee, nil
 		}
 	}
@@ -11,6 +11,7 @@ import (
 	ethereum "github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/harmony-one/harmony/block"
@@ -78,6 +79,24 @@ func (c *Client) BlockByNumber(ctx context.Context, number *big.Int) (*types.Blo
 	return c.getBlock(ctx, "hmy_getBlockByNumber", toBlockNumArg(number), true)
 }
 
+// BlockSummaryByNumber returns the summary of a block from the current
+// canonical chain. If number is nil, the latest known block is summarized.
+func (c *Client) BlockSummaryByNumber(ctx context.Context, number *big.Int) (*BlockSummary, error) {
+	var raw *rpcBlockSummary
+	err := c.c.CallContext(ctx, &raw, "hmy_getBlockByNumber", toBlockNumArg(number), false)
+	if err != nil {
+		return nil, err
+	} else if raw == nil {
+		return nil, ethereum.NotFound
+	}
+	return &BlockSummary{
+		Number:    raw.Number.ToInt().Uint64(),
+		Hash:      raw.Hash,
+		Timestamp: uint64(raw.Timestamp),
+		TxCount:   len(raw.Transactions) + len(raw.StakingTxs),
+	}, nil
+}
+
 // NetworkID returns the network ID (also known as the chain ID) for this chain.
 func (c *Client) NetworkID(ctx context.Context) (*big.Int, error) {
 	version := new(big.Int)
@@ -91,6 +110,100 @@ func (c *Client) NetworkID(ctx context.Context) (*big.Int, error) {
 	return version, nil
 }
 
+// Leader returns the address of the current leader of the given shard. The
+// node must belong to the shard.
+func (c *Client) Leader(ctx context.Context, shardID uint32) (string, error) {
+	membership, err := c.ShardMembership(ctx, shardID)
+	if err != nil {
+		return "", err
+	}
+	if membership.Leader == "" {
+		return "", fmt.Errorf("leader of shard %d unknown to the node", shardID)
+	}
+	return membership.Leader, nil
+}
+
+// ShardMembership returns the validators of the given shard as of the
+// current epoch, and its leader if the node belongs to the shard, all as of
+// the same block. Beacon chain nodes know the validators of every shard. It
+// can be called at any time to pick up committee and leader changes.
+func (c *Client) ShardMembership(ctx context.Context, shardID uint32) (*ShardMembership, error) {
+	var raw rpcShardMembership
+	if err := c.c.CallContext(ctx, &raw, "hmy_getShardMembership", shardID); err != nil {
+		return nil, err
+	}
+	return &ShardMembership{
+		ShardID:    raw.ShardID,
+		Epoch:      uint64(raw.Epoch),
+		Validators: raw.Validators,
+		Leader:     raw.Leader,
+	}, nil
+}
+
+// PendingNonceAt returns the account nonce of the given account in the
+// pending state. This is the nonce that should be used for the next
+// transaction.
+func (c *Client) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	var result hexutil.Uint64
+	err := c.c.CallContext(ctx, &result, "hmy_getTransactionCount", account.Hex(), "pending")
+	return uint64(result), err
+}
+
+// SendTransaction injects a signed transaction into the pending pool for
+// execution.
+func (c *Client) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	data, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		return err
+	}
+	return c.c.CallContext(ctx, nil, "hmy_sendRawTransaction", hexutil.Encode(data))
+}
+
+// TransactionInclusion returns the block including the transaction with the
+// given hash, or ethereum.NotFound if the transaction is not in the chain yet.
+func (c *Client) TransactionInclusion(ctx context.Context, hash common.Hash) (*TransactionInclusion, error) {
+	var raw *rpcReceipt
+	err := c.c.CallContext(ctx, &raw, "hmy_getTransactionReceipt", hash)
+	if err != nil {
+		return nil, err
+	} else if raw == nil {
+		return nil, ethereum.NotFound
+	}
+	inclusion := &TransactionInclusion{
+		BlockNumber: uint64(raw.BlockNumber),
+		BlockHash:   raw.BlockHash,
+		Index:       uint64(raw.TransactionIndex),
+		// receipts before Byzantium have a post state root instead of a
+		// status; take them for successful
+		Status: types.ReceiptStatusSuccessful,
+	}
+	if raw.Status != nil {
+		inclusion.Status = uint64(*raw.Status)
+	}
+	return inclusion, nil
+}
+
+// CXReceipt returns the cross-shard receipt of the transaction with the given
+// hash, if the queried node is on the destination shard and has applied the
+// receipt, or ethereum.NotFound otherwise.
+func (c *Client) CXReceipt(ctx context.Context, hash common.Hash) (*CXReceipt, error) {
+	var raw *rpcCXReceipt
+	err := c.c.CallContext(ctx, &raw, "hmy_getCXReceiptByHash", hash)
+	if err != nil {
+		return nil, err
+	} else if raw == nil {
+		return nil, ethereum.NotFound
+	}
+	return &CXReceipt{
+		BlockNumber: raw.BlockNumber.ToInt().Uint64(),
+		BlockHash:   raw.BlockHash,
+		TxHash:      raw.TxHash,
+		ShardID:     raw.ShardID,
+		ToShardID:   raw.ToShardID,
+		Amount:      raw.Amount.ToInt(),
+	}, nil
+}
+
 func (c *Client) getBlock(ctx context.Context, method string, args ...interface{}) (*types.Block, error) {
 	var raw json.RawMessage
 	err := c.c.CallContext(ctx, &raw, method, args...)
@@ -0,0 +1,127 @@
+package hmyclient
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+)
+
+// FakeHmyAPI serves the hmy namespace methods used by the tests; the RPC
+// server only registers exported types.
+type FakeHmyAPI struct {
+	receipts   map[common.Hash]map[string]interface{}
+	cxReceipts map[common.Hash]map[string]interface{}
+}
+
+func (api *FakeHmyAPI) GetTransactionReceipt(ctx context.Context, hash common.Hash) (map[string]interface{}, error) {
+	return api.receipts[hash], nil
+}
+
+func (api *FakeHmyAPI) GetCXReceiptByHash(ctx context.Context, hash common.Hash) map[string]interface{} {
+	return api.cxReceipts[hash]
+}
+
+func (api *FakeHmyAPI) GetShardMembership(ctx context.Context, shardID uint32) (map[string]interface{}, error) {
+	if shardID > 1 {
+		return nil, errors.Errorf("no committee of shard %d", shardID)
+	}
+	leader := ""
+	if shardID == 0 {
+		leader = "one1leader"
+	}
+	return map[string]interface{}{
+		"shardID":    shardID,
+		"epoch":      hexutil.Uint64(3),
+		"validators": []string{"one1leader", "one1other"},
+		"leader":     leader,
+	}, nil
+}
+
+func newTestClient(t *testing.T, api *FakeHmyAPI) *Client {
+	server := rpc.NewServer()
+	if err := server.RegisterName("hmy", api); err != nil {
+		t.Fatal(err)
+	}
+	return NewClient(rpc.DialInProc(server))
+}
+
+func TestTransactionInclusion(t *testing.T) {
+	succeeded, failed, legacy := common.Hash{1}, common.Hash{2}, common.Hash{3}
+	client := newTestClient(t, &FakeHmyAPI{receipts: map[common.Hash]map[string]interface{}{
+		succeeded: {"blockNumber": hexutil.Uint64(5), "transactionIndex": hexutil.Uint64(1), "status": hexutil.Uint(1)},
+		failed:    {"blockNumber": hexutil.Uint64(6), "transactionIndex": hexutil.Uint64(0), "status": hexutil.Uint(0)},
+		legacy:    {"blockNumber": hexutil.Uint64(7), "transactionIndex": hexutil.Uint64(0), "root": hexutil.Bytes{1}},
+	}})
+	defer client.Close()
+
+	for _, test := range []struct {
+		hash        common.Hash
+		blockNumber uint64
+		failed      bool
+	}{
+		{succeeded, 5, false},
+		{failed, 6, true},
+		{legacy, 7, false},
+	} {
+		inclusion, err := client.TransactionInclusion(context.Background(), test.hash)
+		if err != nil {
+			t.Fatalf("TransactionInclusion(%x) failed: %s", test.hash, err)
+		}
+		if inclusion.BlockNumber != test.blockNumber || inclusion.Failed() != test.failed {
+			t.Errorf("TransactionInclusion(%x) = %+v", test.hash, inclusion)
+		}
+	}
+	if _, err := client.TransactionInclusion(context.Background(), common.Hash{4}); err != ethereum.NotFound {
+		t.Errorf("got error %v for a missing transaction", err)
+	}
+}
+
+func TestCXReceipt(t *testing.T) {
+	hash := common.Hash{1}
+	client := newTestClient(t, &FakeHmyAPI{cxReceipts: map[common.Hash]map[string]interface{}{
+		hash: {
+			"blockHash": common.Hash{2}, "blockNumber": (*hexutil.Big)(big.NewInt(9)), "hash": hash,
+			"shardID": 0, "toShardID": 1, "value": (*hexutil.Big)(big.NewInt(100)),
+		},
+	}})
+	defer client.Close()
+
+	cx, err := client.CXReceipt(context.Background(), hash)
+	if err != nil {
+		t.Fatalf("CXReceipt() failed: %s", err)
+	}
+	if cx.BlockNumber != 9 || cx.TxHash != hash || cx.ToShardID != 1 || cx.Amount.Int64() != 100 {
+		t.Errorf("CXReceipt() = %+v", cx)
+	}
+	if _, err := client.CXReceipt(context.Background(), common.Hash{3}); err != ethereum.NotFound {
+		t.Errorf("got error %v for a missing receipt", err)
+	}
+}
+
+func TestShardMembership(t *testing.T) {
+	client := newTestClient(t, &FakeHmyAPI{})
+	defer client.Close()
+
+	membership, err := client.ShardMembership(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("ShardMembership() failed: %s", err)
+	}
+	if membership.ShardID != 1 || membership.Epoch != 3 || len(membership.Validators) != 2 || membership.Leader != "" {
+		t.Errorf("ShardMembership() = %+v", membership)
+	}
+	if leader, err := client.Leader(context.Background(), 0); err != nil || leader != "one1leader" {
+		t.Errorf("Leader(0) = %#v, %v", leader, err)
+	}
+	if _, err := client.Leader(context.Background(), 1); err == nil {
+		t.Error("expected error for the leader of another shard")
+	}
+	if _, err := client.ShardMembership(context.Background(), 2); err == nil {
+		t.Error("expected error for a shard without committee")
+	}
+}
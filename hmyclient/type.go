@@ -1,7 +1,10 @@
 package hmyclient
 
 import (
+	"math/big"
+
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/harmony-one/harmony/core/types"
 	types2 "github.com/harmony-one/harmony/staking/types"
 )
@@ -28,3 +31,77 @@ type rpcStakingTransaction struct {
 	tx *types2.StakingTransaction
 	txExtraInfo
 }
+
+// ShardMembership is the committee of a shard as seen by the queried node.
+// Leader is empty if the node does not belong to the shard.
+type ShardMembership struct {
+	ShardID    uint32
+	Epoch      uint64
+	Validators []string
+	Leader     string
+}
+
+// BlockSummary is the part of a block header needed to measure the
+// throughput of a shard, without the transactions themselves.
+type BlockSummary struct {
+	Number    uint64
+	Hash      common.Hash
+	Timestamp uint64
+	TxCount   int // plain and staking transactions
+}
+
+type rpcBlockSummary struct {
+	Number       hexutil.Big    `json:"number"`
+	Hash         common.Hash    `json:"hash"`
+	Timestamp    hexutil.Uint64 `json:"timestamp"`
+	Transactions []common.Hash  `json:"transactions"`
+	StakingTxs   []common.Hash  `json:"stakingTransactions"`
+}
+
+// TransactionInclusion tells where a transaction was included in the chain,
+// and whether it succeeded there.
+type TransactionInclusion struct {
+	BlockNumber uint64
+	BlockHash   common.Hash
+	Index       uint64
+	Status      uint64 // types.ReceiptStatusSuccessful or types.ReceiptStatusFailed
+}
+
+// Failed tells whether the transaction failed, e.g. ran out of gas, although
+// it was included.
+func (inclusion *TransactionInclusion) Failed() bool {
+	return inclusion.Status == types.ReceiptStatusFailed
+}
+
+type rpcReceipt struct {
+	BlockNumber      hexutil.Uint64  `json:"blockNumber"`
+	BlockHash        common.Hash     `json:"blockHash"`
+	TransactionIndex hexutil.Uint64  `json:"transactionIndex"`
+	Status           *hexutil.Uint64 `json:"status"` // absent for receipts with a post state root
+}
+
+// CXReceipt is a cross-shard receipt as applied on its destination shard.
+type CXReceipt struct {
+	BlockNumber uint64 // of the destination shard block that applied it
+	BlockHash   common.Hash
+	TxHash      common.Hash
+	ShardID     uint32
+	ToShardID   uint32
+	Amount      *big.Int
+}
+
+type rpcCXReceipt struct {
+	BlockHash   common.Hash  `json:"blockHash"`
+	BlockNumber *hexutil.Big `json:"blockNumber"`
+	TxHash      common.Hash  `json:"hash"`
+	ShardID     uint32       `json:"shardID"`
+	ToShardID   uint32       `json:"toShardID"`
+	Amount      *hexutil.Big `json:"value"`
+}
+
+type rpcShardMembership struct {
+	ShardID    uint32         `json:"shardID"`
+	Epoch      hexutil.Uint64 `json:"epoch"`
+	Validators []string       `json:"validators"`
+	Leader     string         `json:"leader"`
+}
@@ -90,6 +90,8 @@ type ConfigType struct {
 	ConsensusPubKey *multibls.PublicKey
 	// Database directory
 	DBDir            string
+	GenesisFile      string // genesis spec; empty for the network type's built-in genesis
+	MinGasPrice      uint64 // minimum gas price accepted into the tx pool; 0 for the default
 	networkType      NetworkType
 	shardingSchedule shardingconfig.Schedule
 	DNSZone          string
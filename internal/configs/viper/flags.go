@@ -0,0 +1,149 @@
+package viperconfig
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// commandOnlyFlags are the flags that only make sense on the command line
+var commandOnlyFlags = map[string]bool{
+	"print_config": true,
+	"version":      true,
+}
+
+// processFlags are the flags identifying a process, which the binaries reading
+// a section of the config file must not take from its top level
+var processFlags = map[string]bool{
+	"key":        true,
+	"port":       true,
+	"pprof_port": true,
+}
+
+// resetter is implemented by the flag values that can only be set once, such
+// as lists, to be emptied before being overridden
+type resetter interface {
+	Reset()
+}
+
+// ResetConfFlags resets the flags of the flag set to their values from the
+// environment and the config file, looked up under the section, then at the
+// top level shared with harmony: e.g. for the ip flag in the txgen section,
+// HARMONY_TXGEN_IP, HARMONY_IP, "txgen.ip" and "ip". The flags identifying
+// the process, such as port, are only looked up under the section.
+// The precedence is environment, then command line, then config file, then
+// the legacy HMY_ variables read by the ResetConf* functions.
+// Unlike the ResetConf* functions, zero values in the config file apply.
+func ResetConfFlags(fs *flag.FlagSet, envViper *viper.Viper, configFileViper *viper.Viper, sectionName string) error {
+	explicit := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+
+	var err error
+	fs.VisitAll(func(f *flag.Flag) {
+		if err != nil || commandOnlyFlags[f.Name] {
+			return
+		}
+		sections := []string{sectionName}
+		if sectionName != "" && !processFlags[f.Name] {
+			sections = append(sections, "")
+		}
+		var source, value string
+		ok := false
+		for _, section := range sections {
+			source = EnvOverrideName(section, f.Name)
+			if value, ok = os.LookupEnv(source); ok && value != "" {
+				break
+			}
+			ok = false
+		}
+		if !ok && explicit[f.Name] {
+			return
+		}
+		for _, section := range sections {
+			if ok {
+				break
+			}
+			source = getConfName(section, f.Name)
+			value, ok = confValue(configFileViper, source)
+		}
+		if !ok {
+			source = "HMY" + strings.ToUpper(getEnvName(sectionName, f.Name))
+			value = envViper.GetString(getEnvName(sectionName, f.Name))
+			ok = value != ""
+		}
+		if ok {
+			if r, isResetter := f.Value.(resetter); isResetter {
+				r.Reset()
+			}
+			if e := fs.Set(f.Name, value); e != nil {
+				err = errors.Wrapf(e, "invalid value %#v for %s", value, source)
+			}
+		}
+	})
+	return err
+}
+
+// EnvOverrideName returns the name of the environment variable overriding the
+// flag in the config section.
+func EnvOverrideName(sectionName string, flagName string) string {
+	name := "HARMONY_" + flagName
+	if sectionName != "" {
+		name = "HARMONY_" + sectionName + "_" + flagName
+	}
+	return strings.ToUpper(name)
+}
+
+// confValue returns the config file value of the key as a flag string.
+// Lists, e.g. of bootnodes, are joined with commas.
+func confValue(configFileViper *viper.Viper, key string) (string, bool) {
+	if !configFileViper.IsSet(key) {
+		return "", false
+	}
+	switch value := configFileViper.Get(key).(type) {
+	case []interface{}:
+		strs := make([]string, len(value))
+		for i, v := range value {
+			strs[i] = fmt.Sprint(v)
+		}
+		return strings.Join(strs, ","), true
+	case nil:
+		return "", false
+	default:
+		return fmt.Sprint(value), true
+	}
+}
+
+// PrintConfig writes the effective value of every flag of the flag set in the
+// JSON config file format, under the section if not empty, so that the output
+// can be saved as a config file shared by the binaries.
+func PrintConfig(w io.Writer, fs *flag.FlagSet, sectionName string) error {
+	conf := make(map[string]interface{})
+	fs.VisitAll(func(f *flag.Flag) {
+		if commandOnlyFlags[f.Name] {
+			return
+		}
+		conf[f.Name] = f.Value.String()
+		if getter, ok := f.Value.(flag.Getter); ok {
+			switch value := getter.Get().(type) {
+			case bool, int, int64, uint, uint64, float64:
+				conf[f.Name] = value
+			}
+		}
+	})
+	var out interface{} = conf
+	if sectionName != "" {
+		out = map[string]interface{}{sectionName: conf}
+	}
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "cannot marshal config")
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
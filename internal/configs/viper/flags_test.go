@@ -0,0 +1,154 @@
+package viperconfig
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	p2putils "github.com/harmony-one/harmony/p2p/utils"
+	"github.com/spf13/viper"
+)
+
+func newTestFlagSet() (*flag.FlagSet, *string, *int, *bool, *time.Duration) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	ip := fs.String("ip", "127.0.0.1", "")
+	port := fs.Int("port", 9000, "")
+	logConn := fs.Bool("log_conn", true, "")
+	duration := fs.Duration("duration", time.Minute, "")
+	fs.Bool("version", false, "")
+	return fs, ip, port, logConn, duration
+}
+
+func TestResetConfFlags(t *testing.T) {
+	dir, err := ioutil.TempDir("", "viperconfig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	conf := `{"ip": "10.0.0.1", "port": 9300, "txgen": {"port": 9100, "log_conn": false}, "bootnode": {"duration": "5s"}}`
+	if err := ioutil.WriteFile(filepath.Join(dir, "nodeconfig.json"), []byte(conf), 0600); err != nil {
+		t.Fatal(err)
+	}
+	configFileViper, err := CreateConfFileViper(dir, "nodeconfig", "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs, ip, port, logConn, duration := newTestFlagSet()
+	if err := fs.Parse([]string{"-port", "9200"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ResetConfFlags(fs, CreateEnvViper(), configFileViper, "txgen"); err != nil {
+		t.Fatal(err)
+	}
+	// the command line overrides the file, the zero value in the file applies
+	// and the top level ip is shared
+	if *ip != "10.0.0.1" || *port != 9200 || *logConn || *duration != time.Minute {
+		t.Errorf("txgen section: got %v %v %v %v", *ip, *port, *logConn, *duration)
+	}
+
+	fs, ip, _, _, duration = newTestFlagSet()
+	if err := ResetConfFlags(fs, CreateEnvViper(), configFileViper, ""); err != nil {
+		t.Fatal(err)
+	}
+	if *ip != "10.0.0.1" || *duration != time.Minute {
+		t.Errorf("top level: got %v %v", *ip, *duration)
+	}
+
+	fs, ip, port, _, duration = newTestFlagSet()
+	if err := ResetConfFlags(fs, CreateEnvViper(), configFileViper, "bootnode"); err != nil {
+		t.Fatal(err)
+	}
+	// the top level port is harmony's
+	if *ip != "10.0.0.1" || *port != 9000 || *duration != 5*time.Second {
+		t.Errorf("bootnode section: got %v %v %v", *ip, *port, *duration)
+	}
+
+	fs, _, _, _, _ = newTestFlagSet()
+	if err := ResetConfFlags(fs, CreateEnvViper(), configFileViper, "txgen"); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := PrintConfig(&buf, fs, "txgen"); err != nil {
+		t.Fatal(err)
+	}
+	expected := `{
+  "txgen": {
+    "duration": "1m0s",
+    "ip": "10.0.0.1",
+    "log_conn": false,
+    "port": 9100
+  }
+}
+`
+	if buf.String() != expected {
+		t.Errorf("got config\n%s\nexpected\n%s", buf.String(), expected)
+	}
+}
+
+func TestResetConfFlagsEnv(t *testing.T) {
+	dir, err := ioutil.TempDir("", "viperconfig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	conf := `{"txgen": {"ip": "10.0.0.1", "port": 9100, "log_conn": false}}`
+	if err := ioutil.WriteFile(filepath.Join(dir, "nodeconfig.json"), []byte(conf), 0600); err != nil {
+		t.Fatal(err)
+	}
+	configFileViper, err := CreateConfFileViper(dir, "nodeconfig", "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, value := range map[string]string{
+		"HARMONY_TXGEN_PORT":     "9300",
+		"HARMONY_TXGEN_DURATION": "2s",
+		"HARMONY_PORT":           "9400",
+		"HARMONY_LOG_CONN":       "true",
+	} {
+		os.Setenv(name, value)
+		defer os.Unsetenv(name)
+	}
+
+	fs, ip, port, logConn, duration := newTestFlagSet()
+	if err := fs.Parse([]string{"-port", "9200", "-ip", "10.0.0.2"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ResetConfFlags(fs, CreateEnvViper(), configFileViper, "txgen"); err != nil {
+		t.Fatal(err)
+	}
+	// environment > command line > config file, and the top level environment
+	// applies but to the port of the process
+	if *ip != "10.0.0.2" || *port != 9300 || !*logConn || *duration != 2*time.Second {
+		t.Errorf("got %v %v %v %v", *ip, *port, *logConn, *duration)
+	}
+
+	os.Setenv("HARMONY_TXGEN_LOG_CONN", "maybe")
+	defer os.Unsetenv("HARMONY_TXGEN_LOG_CONN")
+	fs, _, _, _, _ = newTestFlagSet()
+	if err := ResetConfFlags(fs, CreateEnvViper(), configFileViper, "txgen"); err == nil {
+		t.Error("invalid environment value accepted")
+	}
+}
+
+func TestResetConfFlagsEnvList(t *testing.T) {
+	os.Setenv("HARMONY_TXGEN_BOOTNODES", "/ip4/127.0.0.1/tcp/19877/p2p/QmVdSe4RdeC3xPemFuRG9Mhzo28EXbiBZh1tpjsuMbHqh1")
+	defer os.Unsetenv("HARMONY_TXGEN_BOOTNODES")
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	var bootnodes p2putils.AddrList
+	fs.Var(&bootnodes, "bootnodes", "")
+	if err := fs.Parse([]string{"-bootnodes", "/ip4/127.0.0.1/tcp/19876/p2p/QmVdSe4RdeC3xPemFuRG9Mhzo28EXbiBZh1tpjsuMbHqh1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ResetConfFlags(fs, CreateEnvViper(), viper.New(), "txgen"); err != nil {
+		t.Fatal(err)
+	}
+	if len(bootnodes) != 1 || bootnodes[0].String() != "/ip4/127.0.0.1/tcp/19877/p2p/QmVdSe4RdeC3xPemFuRG9Mhzo28EXbiBZh1tpjsuMbHqh1" {
+		t.Errorf("got bootnodes %v", bootnodes.String())
+	}
+}
@@ -2,8 +2,8 @@ package viperconfig
 
 import (
 	"bytes"
-	"fmt"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 )
 
@@ -18,7 +18,8 @@ func CreateEnvViper() *viper.Viper {
 
 // CreateConfFileViper creates viper to read from config file
 // Now the config file is JSON type, name is "config.json"
-func CreateConfFileViper(filePath, confName, confType string) *viper.Viper {
+// A missing config file is not an error.
+func CreateConfFileViper(filePath, confName, confType string) (*viper.Viper, error) {
 	configFileViper := viper.New()
 	configFileViper.SetConfigName(confName) // name of config file (without extension)
 	configFileViper.SetConfigType(confType) // REQUIRED if the config file does not have the extension in the name
@@ -27,10 +28,10 @@ func CreateConfFileViper(filePath, confName, confType string) *viper.Viper {
 
 	if err := configFileViper.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
-			panic(fmt.Errorf("fatal error config file: %s", err))
+			return nil, errors.Wrapf(err, "cannot read config file %s", configFileViper.ConfigFileUsed())
 		}
 	}
-	return configFileViper
+	return configFileViper, nil
 }
 
 func getEnvName(sectionName string, flagName string) string {
@@ -0,0 +1,26 @@
+package viperconfig
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCreateConfFileViper(t *testing.T) {
+	dir, err := ioutil.TempDir("", "viperconfig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if _, err := CreateConfFileViper(dir, "nodeconfig", "json"); err != nil {
+		t.Errorf("missing config file: %v", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "nodeconfig.json"), []byte(`{"ip":`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CreateConfFileViper(dir, "nodeconfig", "json"); err == nil {
+		t.Error("invalid config file accepted")
+	}
+}
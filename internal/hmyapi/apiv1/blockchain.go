@@ -9,6 +9,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/harmony-one/bls/ffi/go/bls"
 	"github.com/harmony-one/harmony/common/denominations"
@@ -184,6 +185,40 @@ func (s *PublicBlockChainAPI) GetValidators(ctx context.Context, epoch int64) (m
 	return result, nil
 }
 
+// GetShardMembership returns the validators of the given shard as of the
+// current epoch, and its current leader if the node belongs to the shard,
+// both read at the same head block. Beacon chain nodes know the committees
+// of all shards.
+func (s *PublicBlockChainAPI) GetShardMembership(ctx context.Context, shardID uint32) (map[string]interface{}, error) {
+	header := s.b.CurrentBlock().Header()
+	committee, err := s.b.GetCommittee(header.Epoch(), shardID)
+	if err != nil {
+		return nil, err
+	}
+	if committee == nil {
+		return nil, errors.Errorf("no committee of shard %d in epoch %v", shardID, header.Epoch())
+	}
+	validators := make([]string, 0, len(committee.Slots))
+	for _, validator := range committee.Slots {
+		oneAddress, err := internal_common.AddressToBech32(validator.EcdsaAddress)
+		if err != nil {
+			return nil, err
+		}
+		validators = append(validators, oneAddress)
+	}
+	leader := ""
+	if header.ShardID() == shardID {
+		leader = newHeaderInformation(header).Leader
+	}
+	result := map[string]interface{}{
+		"shardID":    shardID,
+		"epoch":      hexutil.Uint64(header.Epoch().Uint64()),
+		"validators": validators,
+		"leader":     leader,
+	}
+	return result, nil
+}
+
 // IsLastBlock checks if block is last epoch block.
 func (s *PublicBlockChainAPI) IsLastBlock(blockNum uint64) (bool, error) {
 	if s.b.GetShardID() == shard.BeaconChainShardID {
@@ -377,6 +412,58 @@ func (s *PublicBlockChainAPI) GetStorageAt(ctx context.Context, addr string, key
 	return res[:], state.Error()
 }
 
+// GetProof returns the Merkle proof of the account and of the given storage
+// keys under the state root of the given block.
+func (s *PublicBlockChainAPI) GetProof(ctx context.Context, addr string, storageKeys []string, blockNr rpc.BlockNumber) (*AccountResult, error) {
+	address := internal_common.ParseAddr(addr)
+	state, _, err := s.b.StateAndHeaderByNumber(ctx, blockNr)
+	if state == nil || err != nil {
+		return nil, err
+	}
+
+	storageTrie := state.StorageTrie(address)
+	storageHash := types.EmptyRootHash
+	codeHash := state.GetCodeHash(address)
+	storageProof := make([]StorageResult, len(storageKeys))
+
+	// if we have a storageTrie, the account exists and we must update
+	// the storage root hash and the proofs for the storage keys
+	if storageTrie != nil {
+		storageHash = storageTrie.Hash()
+	} else {
+		// no storageTrie means the account does not exist, so the codeHash is the hash of an empty bytearray
+		codeHash = crypto.Keccak256Hash(nil)
+	}
+
+	for i, key := range storageKeys {
+		if storageTrie == nil {
+			storageProof[i] = StorageResult{key, &hexutil.Big{}, []string{}}
+			continue
+		}
+		proof, err := state.GetStorageProof(address, common.HexToHash(key))
+		if err != nil {
+			return nil, err
+		}
+		value := state.GetState(address, common.HexToHash(key)).Big()
+		storageProof[i] = StorageResult{key, (*hexutil.Big)(value), common.ToHexArray(proof)}
+	}
+
+	accountProof, err := state.GetProof(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AccountResult{
+		Address:      addr,
+		AccountProof: common.ToHexArray(accountProof),
+		Balance:      (*hexutil.Big)(state.GetBalance(address)),
+		CodeHash:     codeHash,
+		Nonce:        hexutil.Uint64(state.GetNonce(address)),
+		StorageHash:  storageHash,
+		StorageProof: storageProof,
+	}, state.Error()
+}
+
 // GetBalanceByBlockNumber returns balance by block number.
 func (s *PublicBlockChainAPI) GetBalanceByBlockNumber(ctx context.Context, address string, blockNr rpc.BlockNumber) (*hexutil.Big, error) {
 	addr := internal_common.ParseAddr(address)
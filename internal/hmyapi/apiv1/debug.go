@@ -20,7 +20,7 @@ func NewDebugAPI(b Backend) *DebugAPI {
 
 // SetLogVerbosity Sets log verbosity on runtime
 // Example usage:
-//  curl -H "Content-Type: application/json" -d '{"method":"debug_setLogVerbosity","params":[0],"id":1}' http://localhost:9123
+//  curl -H "Content-Type: application/json" -d '{"method":"hmy_setLogVerbosity","params":[0],"id":1}' http://localhost:9500
 func (*DebugAPI) SetLogVerbosity(ctx context.Context, level int) (map[string]interface{}, error) {
 	if level < int(log.LvlCrit) || level > int(log.LvlTrace) {
 		return nil, errors.New("invalid log level")
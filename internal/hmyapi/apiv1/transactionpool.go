@@ -122,6 +122,32 @@ func (s *PublicTransactionPoolAPI) GetTransactionByHash(ctx context.Context, has
 	return nil
 }
 
+// GetTransactionProof returns the Merkle proof of the given transaction under
+// the transactions root of the block that includes it.
+func (s *PublicTransactionPoolAPI) GetTransactionProof(ctx context.Context, hash common.Hash) (*TransactionProof, error) {
+	tx, blockHash, _, index := rawdb.ReadTransaction(s.b.ChainDb(), hash)
+	if tx == nil {
+		return nil, errors.Errorf("transaction %s not found", hash.Hex())
+	}
+	block, err := s.b.GetBlock(ctx, blockHash)
+	if block == nil {
+		return nil, err
+	}
+	proof, err := types.DeriveShaProof(
+		uint(index), block.Transactions(), block.StakingTransactions(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &TransactionProof{
+		BlockHash:        blockHash,
+		BlockNumber:      (*hexutil.Big)(block.Number()),
+		TransactionIndex: hexutil.Uint64(index),
+		TransactionsRoot: block.Header().TxHash(),
+		Proof:            common.ToHexArray(proof),
+	}, nil
+}
+
 // GetStakingTransactionByHash returns the staking transaction for the given hash
 func (s *PublicTransactionPoolAPI) GetStakingTransactionByHash(ctx context.Context, hash common.Hash) *RPCStakingTransaction {
 	// Try to return an already finalized transaction
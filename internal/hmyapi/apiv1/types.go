@@ -355,6 +355,36 @@ func newRPCPendingStakingTransaction(tx *types2.StakingTransaction) *RPCStakingT
 	return newRPCStakingTransaction(tx, common.Hash{}, 0, 0, 0)
 }
 
+// AccountResult is the Merkle proof of an account and some of its storage
+// slots under a block's state root
+type AccountResult struct {
+	Address      string          `json:"address"`
+	AccountProof []string        `json:"accountProof"`
+	Balance      *hexutil.Big    `json:"balance"`
+	CodeHash     common.Hash     `json:"codeHash"`
+	Nonce        hexutil.Uint64  `json:"nonce"`
+	StorageHash  common.Hash     `json:"storageHash"`
+	StorageProof []StorageResult `json:"storageProof"`
+}
+
+// StorageResult is the Merkle proof of a storage slot under an account's
+// storage root
+type StorageResult struct {
+	Key   string       `json:"key"`
+	Value *hexutil.Big `json:"value"`
+	Proof []string     `json:"proof"`
+}
+
+// TransactionProof is the Merkle proof of a transaction under the
+// transactions root of its block
+type TransactionProof struct {
+	BlockHash        common.Hash    `json:"blockHash"`
+	BlockNumber      *hexutil.Big   `json:"blockNumber"`
+	TransactionIndex hexutil.Uint64 `json:"transactionIndex"`
+	TransactionsRoot common.Hash    `json:"transactionsRoot"`
+	Proof            []string       `json:"proof"`
+}
+
 // RPCBlock represents a block that will serialize to the RPC representation of a block
 type RPCBlock struct {
 	Number           *hexutil.Big     `json:"number"`
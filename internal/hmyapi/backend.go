@@ -67,6 +67,8 @@ type Backend interface {
 	GetBalance(ctx context.Context, address common.Address, blockNr rpc.BlockNumber) (*big.Int, error)
 	// Get validators for a particular epoch
 	GetValidators(epoch *big.Int) (*shard.Committee, error)
+	// Get the committee of a shard for a particular epoch
+	GetCommittee(epoch *big.Int, shardID uint32) (*shard.Committee, error)
 	GetShardID() uint32
 	// Get transactions history for an address
 	GetTransactionsHistory(address, txType, order string) ([]common.Hash, error)
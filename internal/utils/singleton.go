@@ -4,6 +4,7 @@ package utils
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path"
 	"sync"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/natefinch/lumberjack"
+	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/diode"
 	"golang.org/x/sync/singleflight"
@@ -31,6 +33,15 @@ var (
 	// ZeroLog
 	zeroLogger      *zerolog.Logger
 	zeroLoggerLevel = zerolog.Disabled
+
+	// format of the console logs
+	consoleLogJSON bool
+)
+
+// Log formats accepted by SetLogFormat
+const (
+	LogFormatTerminal = "terminal"
+	LogFormatJSON     = "json"
 )
 
 // SetLogContext used to print out loggings of node with port and ip.
@@ -44,22 +55,79 @@ func SetLogContext(_port, _ip string) {
 // SetLogVerbosity specifies the verbosity of global logger
 func SetLogVerbosity(verbosity log.Lvl) {
 	logVerbosity = verbosity
+	applyLogVerbosity()
+}
+
+// applyLogVerbosity lets through the logs of the most verbose package, the
+// others being filtered per package; see SetLogVmodule
+func applyLogVerbosity() {
+	verbosity := maxLogVerbosity()
 	if glogger != nil {
-		glogger.Verbosity(logVerbosity)
+		glogger.Verbosity(verbosity)
 	}
 	updateZeroLogLevel(int(verbosity))
 }
 
+// SetLogFormat selects the format of the logs written to the console, either
+// LogFormatTerminal for humans or LogFormatJSON for log collectors. Log files
+// are always written as JSON. It must be called before the first log line.
+func SetLogFormat(format string) error {
+	switch format {
+	case LogFormatTerminal:
+		consoleLogJSON = false
+	case LogFormatJSON:
+		consoleLogJSON = true
+	default:
+		return errors.Errorf("unknown log format %#v", format)
+	}
+	if zeroLogger != nil {
+		childLogger := Logger().Output(consoleLogWriter())
+		zeroLogger = &childLogger
+	}
+	return nil
+}
+
+// LogFormat returns the format of go-ethereum log handlers matching the
+// format selected with SetLogFormat.
+func LogFormat(usecolor bool) log.Format {
+	if consoleLogJSON {
+		return log.JSONFormat()
+	}
+	return log.TerminalFormat(usecolor)
+}
+
+// LogRotation limits the disk space taken by a log file
+type LogRotation struct {
+	MaxSize    int  // megabytes the file may reach before it is rotated
+	MaxBackups int  // number of rotated files kept; 0 keeps all of them
+	MaxAge     int  // days rotated files are kept; 0 keeps them forever
+	Compress   bool // whether rotated files are gzipped
+}
+
+// NewRotatingLogFile returns a writer appending to the log file, which is
+// rotated according to the given limits.
+func NewRotatingLogFile(filepath string, rotation LogRotation) io.WriteCloser {
+	return &lumberjack.Logger{
+		Filename:   filepath,
+		MaxSize:    rotation.MaxSize,
+		MaxBackups: rotation.MaxBackups,
+		MaxAge:     rotation.MaxAge,
+		Compress:   rotation.Compress,
+	}
+}
+
 // AddLogFile creates a StreamHandler that outputs JSON logs
 // into rotating files with specified max file size
 func AddLogFile(filepath string, maxSize int) {
-	AddLogHandler(log.StreamHandler(&lumberjack.Logger{
-		Filename: filepath,
-		MaxSize:  maxSize,
-		Compress: true,
-	}, log.JSONFormat()))
+	AddRotatingLogFile(filepath, LogRotation{MaxSize: maxSize, Compress: true})
+}
 
-	setZeroLoggerFileOutput(filepath, maxSize)
+// AddRotatingLogFile creates a StreamHandler that outputs JSON logs
+// into files rotated according to the given limits
+func AddRotatingLogFile(filepath string, rotation LogRotation) {
+	AddLogHandler(log.StreamHandler(NewRotatingLogFile(filepath, rotation), log.JSONFormat()))
+
+	setZeroLoggerFileOutput(filepath, rotation)
 }
 
 // AddLogHandler add a log handler
@@ -67,7 +135,7 @@ func AddLogHandler(handler log.Handler) {
 	logHandlers = append(logHandlers, handler)
 	if glogger != nil {
 		multiHandler := log.MultiHandler(logHandlers...)
-		glogger.SetHandler(multiHandler)
+		glogger.SetHandler(vmoduleFilterHandler(multiHandler))
 	}
 }
 
@@ -77,11 +145,11 @@ func GetLogInstance() log.Logger {
 		writer := diode.NewWriter(os.Stdout, 1000, 10*time.Millisecond, func(missed int) {
 			fmt.Printf("Logger Dropped %d messages", missed)
 		})
-		ostream := log.StreamHandler(writer, log.TerminalFormat(false))
+		ostream := log.StreamHandler(writer, LogFormat(false))
 		logHandlers = append(logHandlers, ostream)
 		multiHandler := log.MultiHandler(logHandlers...)
-		glogger = log.NewGlogHandler(multiHandler)
-		glogger.Verbosity(logVerbosity)
+		glogger = log.NewGlogHandler(vmoduleFilterHandler(multiHandler))
+		glogger.Verbosity(maxLogVerbosity())
 		logInstance = log.New("port", port, "ip", ip)
 		logInstance.SetHandler(glogger)
 		log.Root().SetHandler(glogger)
@@ -101,18 +169,16 @@ func setZeroLogContext(port string, ip string) {
 
 // SetZeroLoggerFileOutput sets zeroLogger's output stream
 // to destinated filepath with log file rotation.
-func setZeroLoggerFileOutput(filepath string, maxSize int) error {
+func setZeroLoggerFileOutput(filepath string, rotation LogRotation) error {
 	dir := path.Dir(filepath)
 	filename := path.Base(filepath)
 
 	// Initialize ZeroLogger if it hasn't been already
 	// TODO: zerolog filename prefix can be removed once all loggers
 	// has been replaced
-	childLogger := Logger().Output(&lumberjack.Logger{
-		Filename: fmt.Sprintf("%s/zerolog-%s", dir, filename),
-		MaxSize:  maxSize,
-		Compress: true,
-	})
+	childLogger := Logger().Output(
+		NewRotatingLogFile(fmt.Sprintf("%s/zerolog-%s", dir, filename), rotation),
+	)
 	zeroLogger = &childLogger
 
 	return nil
@@ -166,10 +232,8 @@ func AnalysisEnd(name string, more ...interface{}) {
 func Logger() *zerolog.Logger {
 	if zeroLogger == nil {
 		zerolog.TimeFieldFormat = time.RFC3339Nano
-		writer := diode.NewWriter(os.Stderr, 1000, 10*time.Millisecond, func(missed int) {
-			fmt.Printf("Logger Dropped %d messages", missed)
-		})
-		logger := zerolog.New(zerolog.ConsoleWriter{Out: writer}).
+		logger := zerolog.New(consoleLogWriter()).
+			Hook(vmoduleHook{}).
 			Level(zeroLoggerLevel).
 			With().
 			Caller().
@@ -180,6 +244,16 @@ func Logger() *zerolog.Logger {
 	return zeroLogger
 }
 
+func consoleLogWriter() io.Writer {
+	writer := diode.NewWriter(os.Stderr, 1000, 10*time.Millisecond, func(missed int) {
+		fmt.Printf("Logger Dropped %d messages", missed)
+	})
+	if consoleLogJSON {
+		return writer
+	}
+	return zerolog.ConsoleWriter{Out: writer}
+}
+
 func updateZeroLogLevel(level int) {
 	switch level {
 	case 0:
@@ -1,9 +1,16 @@
 package utils
 
 import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
+
+	"github.com/ethereum/go-ethereum/log"
 )
 
 var NumThreads = 20
@@ -33,3 +40,57 @@ func TestSingleton(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestSetLogFormat(t *testing.T) {
+	defer SetLogFormat(LogFormatTerminal)
+	record := &log.Record{
+		Msg: "hello", Lvl: log.LvlInfo, Ctx: []interface{}{"key", "value"},
+		KeyNames: log.RecordKeyNames{Time: "t", Msg: "msg", Lvl: "lvl"},
+	}
+
+	if err := SetLogFormat(LogFormatJSON); err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(LogFormat(false).Format(record), &fields); err != nil {
+		t.Fatalf("log line is not JSON: %v", err)
+	}
+	if fields["msg"] != "hello" || fields["key"] != "value" {
+		t.Errorf("unexpected log line %v", fields)
+	}
+
+	if err := SetLogFormat(LogFormatTerminal); err != nil {
+		t.Fatal(err)
+	}
+	if line := LogFormat(false).Format(record); json.Valid(line) {
+		t.Errorf("terminal log line is JSON: %s", line)
+	}
+
+	if err := SetLogFormat("xml"); err == nil {
+		t.Error("unknown log format accepted")
+	}
+}
+
+func TestNewRotatingLogFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logrotation")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := NewRotatingLogFile(filepath.Join(dir, "test.log"), LogRotation{MaxSize: 1})
+	defer file.Close()
+	chunk := bytes.Repeat([]byte("x"), 700*1024)
+	for i := 0; i < 2; i++ {
+		if _, err := file.Write(chunk); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 {
+		t.Errorf("expected the log file and one rotated file, got %d files", len(files))
+	}
+}
@@ -103,7 +103,12 @@ func GetAddressFromBlsPubKeyBytes(pubKeyBytes []byte) common.Address {
 	return addr
 }
 
-// GenKeyP2P generates a pair of RSA keys used in libp2p host
+// GenKeyP2P generates a pair of RSA keys used in libp2p host, deterministically
+// derived from the IP and port.
+//
+// Deprecated: the keys are predictable and collide for hosts behind the same
+// NAT. Use GenKeyP2PRand, or LoadKeyFromFile to keep the identity across
+// restarts; GenKeyP2P remains for tests that need reproducible peer IDs.
 func GenKeyP2P(ip, port string) (p2p_crypto.PrivKey, p2p_crypto.PubKey, error) {
 	r := mrand.New(mrand.NewSource(int64(GetUniqueIDFromIPPort(ip, port))))
 	return p2p_crypto.GenerateKeyPairWithReader(p2p_crypto.RSA, 2048, r)
@@ -220,10 +225,7 @@ func LoadKeyFromFile(keyfile string) (key p2p_crypto.PrivKey, pk p2p_crypto.PubK
 		Logger().Info().Msg("Using random private key")
 		key, pk, err = GenKeyP2PRand()
 		if err != nil {
-			Logger().Error().
-				AnErr("GenKeyP2PRand Error", err).
-				Msg("LoadedKeyFromFile")
-			panic(err)
+			return nil, nil, errors.Wrap(err, "cannot generate a random private key")
 		}
 		err = SaveKeyToFile(keyfile, key)
 		if err != nil {
@@ -234,7 +236,10 @@ func LoadKeyFromFile(keyfile string) (key p2p_crypto.PrivKey, pk p2p_crypto.PubK
 		return key, pk, nil
 	}
 	key, pk, err = LoadPrivateKey(keyStruct.Key)
-	return key, pk, err
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "cannot load the private key in %s", keyfile)
+	}
+	return key, pk, nil
 }
 
 // IsPrivateIP checks if an IP address is private or not
@@ -0,0 +1,148 @@
+package utils
+
+import (
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// vmoduleRule sets the verbosity of the logs written from the matching files
+type vmoduleRule struct {
+	pattern *regexp.Regexp
+	level   log.Lvl
+}
+
+var (
+	vmoduleMutex sync.RWMutex
+	vmoduleRules []vmoduleRule
+)
+
+// SetLogVmodule sets per-package log verbosities overriding the one given to
+// SetLogVerbosity. The spec is a comma-separated list of pattern=N in the
+// syntax of glog's -vmodule, where N is a verbosity as for SetLogVerbosity:
+// "consensus=4,p2p/*=2" logs debug messages of the packages whose import path
+// ends in consensus, and only warnings and errors of p2p and its subpackages.
+// The first matching pattern applies. An empty spec removes all the rules.
+func SetLogVmodule(spec string) error {
+	rules, err := parseVmodule(spec)
+	if err != nil {
+		return err
+	}
+	vmoduleMutex.Lock()
+	vmoduleRules = rules
+	vmoduleMutex.Unlock()
+	applyLogVerbosity()
+	return nil
+}
+
+func parseVmodule(spec string) ([]vmoduleRule, error) {
+	var rules []vmoduleRule
+	for _, rule := range strings.Split(spec, ",") {
+		if strings.TrimSpace(rule) == "" {
+			continue
+		}
+		parts := strings.Split(rule, "=")
+		if len(parts) != 2 {
+			return nil, errors.Errorf("invalid vmodule rule %#v, expecting pattern=N", rule)
+		}
+		pattern := strings.TrimSpace(parts[0])
+		level, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if pattern == "" || err != nil || level < int(log.LvlCrit) || level > int(log.LvlTrace) {
+			return nil, errors.Errorf("invalid vmodule rule %#v, expecting pattern=N", rule)
+		}
+		// same matching as log.GlogHandler.Vmodule
+		matcher := ".*"
+		for _, comp := range strings.Split(pattern, "/") {
+			if comp == "*" {
+				matcher += "(/.*)?"
+			} else if comp != "" {
+				matcher += "/" + regexp.QuoteMeta(comp)
+			}
+		}
+		if !strings.HasSuffix(pattern, ".go") {
+			matcher += "/[^/]+\\.go"
+		}
+		rules = append(rules, vmoduleRule{regexp.MustCompile(matcher + "$"), log.Lvl(level)})
+	}
+	return rules, nil
+}
+
+func hasLogVmodule() bool {
+	vmoduleMutex.RLock()
+	defer vmoduleMutex.RUnlock()
+	return len(vmoduleRules) > 0
+}
+
+// logVerbosityAt returns the verbosity of the logs written from the file
+func logVerbosityAt(file string) log.Lvl {
+	vmoduleMutex.RLock()
+	defer vmoduleMutex.RUnlock()
+	for _, rule := range vmoduleRules {
+		if rule.pattern.MatchString(file) {
+			return rule.level
+		}
+	}
+	return logVerbosity
+}
+
+// maxLogVerbosity returns the highest verbosity of any package. The loggers
+// must let it through so that the per-package filters get to see it.
+func maxLogVerbosity() log.Lvl {
+	vmoduleMutex.RLock()
+	defer vmoduleMutex.RUnlock()
+	verbosity := logVerbosity
+	for _, rule := range vmoduleRules {
+		if rule.level > verbosity {
+			verbosity = rule.level
+		}
+	}
+	return verbosity
+}
+
+// vmoduleFilterHandler drops the records above the verbosity of the package
+// they were written from
+func vmoduleFilterHandler(h log.Handler) log.Handler {
+	return log.FuncHandler(func(r *log.Record) error {
+		if hasLogVmodule() && r.Lvl > logVerbosityAt(r.Call.Frame().File) {
+			return nil
+		}
+		return h.Log(r)
+	})
+}
+
+// vmoduleHook is the zerolog counterpart of vmoduleFilterHandler
+type vmoduleHook struct{}
+
+func (vmoduleHook) Run(e *zerolog.Event, level zerolog.Level, msg string) {
+	if !hasLogVmodule() {
+		return
+	}
+	// skip Run, (*Event).msg and (*Event).Msg, Msgf or Send
+	if _, file, _, ok := runtime.Caller(3); ok && zerologLvl(level) > logVerbosityAt(file) {
+		e.Discard()
+	}
+}
+
+func zerologLvl(level zerolog.Level) log.Lvl {
+	switch level {
+	case zerolog.TraceLevel:
+		return log.LvlTrace
+	case zerolog.DebugLevel:
+		return log.LvlDebug
+	case zerolog.InfoLevel:
+		return log.LvlInfo
+	case zerolog.WarnLevel:
+		return log.LvlWarn
+	case zerolog.ErrorLevel:
+		return log.LvlError
+	case zerolog.NoLevel:
+		return log.LvlInfo
+	}
+	return log.LvlCrit
+}
@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/rs/zerolog"
+)
+
+func TestParseVmodule(t *testing.T) {
+	rules, err := parseVmodule("consensus=4, p2p/*=2,,node/node.go=0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		file    string
+		level   log.Lvl
+		matched bool
+	}{
+		{"/src/harmony/consensus/consensus.go", log.LvlDebug, true},
+		{"/src/harmony/consensus/quorum/quorum.go", 0, false},
+		{"/src/harmony/p2p/host.go", log.LvlWarn, true},
+		{"/src/harmony/p2p/host/hostv2/hostv2.go", log.LvlWarn, true},
+		{"/src/harmony/node/node.go", log.LvlCrit, true},
+		{"/src/harmony/node/node_handler.go", 0, false},
+	}
+	for _, test := range tests {
+		var rule *vmoduleRule
+		for i := range rules {
+			if rules[i].pattern.MatchString(test.file) {
+				rule = &rules[i]
+				break
+			}
+		}
+		if (rule != nil) != test.matched {
+			t.Errorf("%s: matched %v, expected %v", test.file, rule != nil, test.matched)
+		} else if rule != nil && rule.level != test.level {
+			t.Errorf("%s: got level %v, expected %v", test.file, rule.level, test.level)
+		}
+	}
+
+	for _, spec := range []string{"consensus", "=3", "consensus=debug", "consensus=6", "a=1=2"} {
+		if _, err := parseVmodule(spec); err == nil {
+			t.Errorf("invalid spec %#v accepted", spec)
+		}
+	}
+}
+
+func TestLogVmodule(t *testing.T) {
+	defer func(verbosity log.Lvl) {
+		SetLogVmodule("")
+		SetLogVerbosity(verbosity)
+	}(logVerbosity)
+
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf).Hook(vmoduleHook{})
+
+	SetLogVerbosity(log.LvlInfo)
+	if err := SetLogVmodule("utils=2"); err != nil {
+		t.Fatal(err)
+	}
+	if maxLogVerbosity() != log.LvlInfo {
+		t.Errorf("got max verbosity %v", maxLogVerbosity())
+	}
+	logger.Info().Msg("info")
+	if buf.Len() != 0 {
+		t.Errorf("info message logged at warn verbosity: %s", buf.String())
+	}
+	logger.Warn().Msgf("%s", "warn")
+	if buf.Len() == 0 {
+		t.Error("warn message not logged at warn verbosity")
+	}
+
+	buf.Reset()
+	if err := SetLogVmodule("utils=4"); err != nil {
+		t.Fatal(err)
+	}
+	if maxLogVerbosity() != log.LvlDebug {
+		t.Errorf("got max verbosity %v", maxLogVerbosity())
+	}
+	logger.Debug().Msg("debug")
+	if buf.Len() == 0 {
+		t.Error("debug message not logged at debug verbosity")
+	}
+}
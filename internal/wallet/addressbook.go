@@ -0,0 +1,93 @@
+package wallet
+
+// this module handles the named addresses of the wallet
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrInvalidAliasName is returned for an empty alias name or a name that
+	// could be mistaken for an address.
+	ErrInvalidAliasName = errors.New("alias name must be non-empty and must not start with one1 or 0x")
+)
+
+// AddressEntry is an address in the address book
+type AddressEntry struct {
+	Address string `json:"address"`
+	// shard the address is usually used on, if known
+	ShardID *uint32 `json:"shardID,omitempty"`
+}
+
+// AddressBook maps alias names to addresses, and is persisted as a JSON file
+type AddressBook struct {
+	file    string
+	entries map[string]AddressEntry
+}
+
+// LoadAddressBook reads the address book from the file, or returns an empty
+// address book if the file does not exist yet.
+func LoadAddressBook(file string) (*AddressBook, error) {
+	book := &AddressBook{file: file, entries: map[string]AddressEntry{}}
+	data, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return book, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &book.entries); err != nil {
+		return nil, errors.Wrapf(err, "cannot parse address book %s", file)
+	}
+	return book, nil
+}
+
+// Save writes the address book back to its file
+func (b *AddressBook) Save() error {
+	data, err := json.MarshalIndent(b.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(b.file), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(b.file, data, 0600)
+}
+
+// Set adds or replaces the address of the alias
+func (b *AddressBook) Set(name string, entry AddressEntry) error {
+	lower := strings.ToLower(name)
+	if name == "" || strings.HasPrefix(lower, "one1") || strings.HasPrefix(lower, "0x") {
+		return ErrInvalidAliasName
+	}
+	b.entries[name] = entry
+	return nil
+}
+
+// Lookup returns the address of the alias
+func (b *AddressBook) Lookup(name string) (AddressEntry, bool) {
+	entry, ok := b.entries[name]
+	return entry, ok
+}
+
+// Remove deletes the alias, and reports whether it existed
+func (b *AddressBook) Remove(name string) bool {
+	_, ok := b.entries[name]
+	delete(b.entries, name)
+	return ok
+}
+
+// Names returns the sorted alias names
+func (b *AddressBook) Names() []string {
+	names := make([]string, 0, len(b.entries))
+	for name := range b.entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
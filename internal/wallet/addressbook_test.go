@@ -0,0 +1,61 @@
+package wallet
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestAddressBook(t *testing.T) {
+	dir, err := ioutil.TempDir("", "addressbook")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "hmy", "addressbook.json")
+
+	book, err := LoadAddressBook(file)
+	if err != nil {
+		t.Fatalf("cannot load missing address book: %v", err)
+	}
+	if len(book.Names()) != 0 {
+		t.Errorf("new address book is not empty: %v", book.Names())
+	}
+
+	shard := uint32(1)
+	alice := AddressEntry{Address: "one1pdv9lrdwl0rg5vglh4xtyrv3wjk3wsqket7zxy", ShardID: &shard}
+	bob := AddressEntry{Address: "0x7c41E0668B551f4f902cFaec05B5Bdca68b124CE"}
+	for _, name := range []string{"", "one1alias", "0xalias", "ONE1alias"} {
+		if err := book.Set(name, bob); err != ErrInvalidAliasName {
+			t.Errorf("Set(%#v): got error %v, expected %v", name, err, ErrInvalidAliasName)
+		}
+	}
+	if err := book.Set("bob", bob); err != nil {
+		t.Fatal(err)
+	}
+	if err := book.Set("alice", alice); err != nil {
+		t.Fatal(err)
+	}
+	if err := book.Save(); err != nil {
+		t.Fatalf("cannot save address book: %v", err)
+	}
+
+	book, err = LoadAddressBook(file)
+	if err != nil {
+		t.Fatalf("cannot load address book: %v", err)
+	}
+	if names := book.Names(); !reflect.DeepEqual(names, []string{"alice", "bob"}) {
+		t.Errorf("got names %v", names)
+	}
+	if entry, ok := book.Lookup("alice"); !ok || !reflect.DeepEqual(entry, alice) {
+		t.Errorf("got alice = %+v, %v", entry, ok)
+	}
+	if !book.Remove("bob") || book.Remove("bob") {
+		t.Error("bob not removed exactly once")
+	}
+	if _, ok := book.Lookup("bob"); ok {
+		t.Error("bob found after removal")
+	}
+}
@@ -0,0 +1,147 @@
+package node
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"time"
+
+	"github.com/harmony-one/harmony/internal/utils"
+)
+
+const dashboardRecentBlocks = 10
+
+// DashboardStatus is the status of the node shown on its dashboard
+type DashboardStatus struct {
+	ShardID        uint32           `json:"shard-id"`
+	Role           string           `json:"role"`
+	IsLeader       bool             `json:"is-leader"`
+	Height         uint64           `json:"height"`
+	Epoch          uint64           `json:"epoch"`
+	ViewID         uint64           `json:"view-id"`
+	ConsensusMode  string           `json:"consensus-mode"`
+	ConsensusPhase string           `json:"consensus-phase"`
+	Peers          []DashboardPeer  `json:"peers"`
+	PendingTxs     int              `json:"pending-txs"`
+	QueuedTxs      int              `json:"queued-txs"`
+	RecentBlocks   []DashboardBlock `json:"recent-blocks"`
+}
+
+// DashboardPeer is a peer the node is connected to
+type DashboardPeer struct {
+	ID      string `json:"id"`
+	Address string `json:"address"`
+}
+
+// DashboardBlock is a recent block of the node's chain
+type DashboardBlock struct {
+	Number     uint64    `json:"number"`
+	Hash       string    `json:"hash"`
+	Time       time.Time `json:"time"`
+	Txs        int       `json:"txs"`
+	StakingTxs int       `json:"staking-txs"`
+}
+
+// DashboardStatus returns the current status of the node.
+func (node *Node) DashboardStatus() DashboardStatus {
+	status := DashboardStatus{
+		ShardID: node.NodeConfig.ShardID,
+		Role:    node.NodeConfig.Role().String(),
+	}
+	if node.Consensus != nil {
+		status.IsLeader = node.Consensus.IsLeader()
+		status.ViewID = node.Consensus.GetViewID()
+		status.ConsensusMode = node.Consensus.Mode().String()
+		status.ConsensusPhase = node.Consensus.Phase().String()
+	}
+	if node.TxPool != nil {
+		status.PendingTxs, status.QueuedTxs = node.TxPool.Stats()
+	}
+	network := node.host.GetP2PHost().Network()
+	for _, peer := range network.Peers() {
+		p := DashboardPeer{ID: peer.Pretty()}
+		if conns := network.ConnsToPeer(peer); len(conns) > 0 {
+			p.Address = conns[0].RemoteMultiaddr().String()
+		}
+		status.Peers = append(status.Peers, p)
+	}
+
+	chain := node.Blockchain()
+	head := chain.CurrentBlock()
+	status.Height = head.NumberU64()
+	status.Epoch = head.Epoch().Uint64()
+	for block := head; block != nil && len(status.RecentBlocks) < dashboardRecentBlocks; {
+		status.RecentBlocks = append(status.RecentBlocks, DashboardBlock{
+			Number:     block.NumberU64(),
+			Hash:       block.Hash().Hex(),
+			Time:       time.Unix(block.Time().Int64(), 0).UTC(),
+			Txs:        len(block.Transactions()),
+			StakingTxs: len(block.StakingTransactions()),
+		})
+		if block.NumberU64() == 0 {
+			break
+		}
+		block = chain.GetBlockByHash(block.ParentHash())
+	}
+	return status
+}
+
+var dashboardTemplate = template.Must(template.New("dashboard").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="5">
+<title>Harmony node, shard {{.ShardID}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.8em; text-align: left; }
+td.hash { font-family: monospace; }
+</style>
+</head>
+<body>
+<h1>Harmony node, shard {{.ShardID}}</h1>
+<table>
+<tr><th>Role</th><td>{{.Role}}{{if .IsLeader}} (leader){{end}}</td></tr>
+<tr><th>Height</th><td>{{.Height}}</td></tr>
+<tr><th>Epoch</th><td>{{.Epoch}}</td></tr>
+<tr><th>View ID</th><td>{{.ViewID}}</td></tr>
+<tr><th>Consensus</th><td>{{.ConsensusMode}}, {{.ConsensusPhase}} phase</td></tr>
+<tr><th>Tx pool</th><td>{{.PendingTxs}} pending, {{.QueuedTxs}} queued</td></tr>
+</table>
+<h2>Recent blocks</h2>
+<table>
+<tr><th>Number</th><th>Hash</th><th>Time</th><th>Txs</th><th>Staking txs</th></tr>
+{{range .RecentBlocks}}<tr><td>{{.Number}}</td><td class="hash">{{.Hash}}</td><td>{{.Time.Format "2006-01-02 15:04:05"}}</td><td>{{.Txs}}</td><td>{{.StakingTxs}}</td></tr>
+{{end}}</table>
+<h2>Peers ({{len .Peers}})</h2>
+<table>
+<tr><th>ID</th><th>Address</th></tr>
+{{range .Peers}}<tr><td class="hash">{{.ID}}</td><td>{{.Address}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+// DashboardHandler serves the status of the node as a web page at / and in
+// JSON at /status.
+func (node *Node) DashboardHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(node.DashboardStatus()); err != nil {
+			utils.Logger().Warn().Err(err).Msg("cannot write dashboard status")
+		}
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := dashboardTemplate.Execute(w, node.DashboardStatus()); err != nil {
+			utils.Logger().Warn().Err(err).Msg("cannot render dashboard")
+		}
+	})
+	return mux
+}
@@ -0,0 +1,102 @@
+package node
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/harmony-one/harmony/consensus"
+	"github.com/harmony-one/harmony/consensus/quorum"
+	bls2 "github.com/harmony-one/harmony/crypto/bls"
+	"github.com/harmony-one/harmony/internal/utils"
+	"github.com/harmony-one/harmony/multibls"
+	"github.com/harmony-one/harmony/p2p"
+	"github.com/harmony-one/harmony/p2p/p2pimpl"
+	"github.com/harmony-one/harmony/shard"
+)
+
+func TestDashboard(t *testing.T) {
+	blsKey := bls2.RandPrivateKey()
+	leader := p2p.Peer{IP: "127.0.0.1", Port: "8884", ConsensusPubKey: blsKey.GetPublicKey()}
+	priKey, _, _ := utils.GenKeyP2P("127.0.0.1", "9904")
+	host, err := p2pimpl.NewHost(&leader, priKey)
+	if err != nil {
+		t.Fatalf("newhost failure: %v", err)
+	}
+	decider := quorum.NewDecider(
+		quorum.SuperMajorityVote, shard.BeaconChainShardID,
+	)
+	consensus, err := consensus.New(
+		host, shard.BeaconChainShardID, leader, multibls.GetPrivateKey(blsKey), decider,
+	)
+	if err != nil {
+		t.Fatalf("Cannot craeate consensus: %v", err)
+	}
+	node := New(host, consensus, testDBFactory, nil, false)
+	server := httptest.NewServer(node.DashboardHandler())
+	defer server.Close()
+
+	// consensus moves on while the dashboard is read; run with -race
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for viewID := uint64(0); ; viewID++ {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			consensus.SetViewID(viewID)
+			consensus.SetLeaderPubKey(blsKey.GetPublicKey())
+		}
+	}()
+	defer wg.Wait()
+	defer close(done)
+
+	resp, err := http.Get(server.URL + "/status")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var status DashboardStatus
+	err = json.NewDecoder(resp.Body).Decode(&status)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.ShardID != shard.BeaconChainShardID || status.Height != 0 ||
+		len(status.RecentBlocks) != 1 || status.ConsensusPhase == "" {
+		t.Errorf("got status %+v", status)
+	}
+	genesisHash := node.Blockchain().CurrentBlock().Hash().Hex()
+	if status.RecentBlocks[0].Hash != genesisHash {
+		t.Errorf("got recent block %+v", status.RecentBlocks[0])
+	}
+
+	resp, err = http.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	page, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(page), genesisHash) {
+		t.Errorf("genesis block missing from the dashboard:\n%s", page)
+	}
+
+	resp, err = http.Get(server.URL + "/missing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("got status %v for a missing page", resp.Status)
+	}
+}
@@ -13,9 +13,12 @@ import (
 	"github.com/harmony-one/harmony/accounts"
 	"github.com/harmony-one/harmony/api/client"
 	clientService "github.com/harmony-one/harmony/api/client/service"
+	"github.com/harmony-one/harmony/api/proto"
+	proto_discovery "github.com/harmony-one/harmony/api/proto/discovery"
 	msg_pb "github.com/harmony-one/harmony/api/proto/message"
 	proto_node "github.com/harmony-one/harmony/api/proto/node"
 	"github.com/harmony-one/harmony/api/service"
+	"github.com/harmony-one/harmony/api/service/networkinfo"
 	"github.com/harmony-one/harmony/api/service/syncing"
 	"github.com/harmony-one/harmony/api/service/syncing/downloader"
 	"github.com/harmony-one/harmony/consensus"
@@ -38,6 +41,7 @@ import (
 	"github.com/harmony-one/harmony/staking/slash"
 	staking "github.com/harmony-one/harmony/staking/types"
 	"github.com/harmony-one/harmony/webhooks"
+	libp2p_peer "github.com/libp2p/go-libp2p-core/peer"
 )
 
 // State is a state of a node.
@@ -125,6 +129,7 @@ type Node struct {
 	// TODO: Neighbors should store only neighbor nodes in the same shard
 	Neighbors  sync.Map   // All the neighbor nodes, key is the sha256 of Peer IP/Port, value is the p2p.Peer
 	numPeers   int        // Number of Peers
+	peerMutex  sync.Mutex // mutex for adding and removing Neighbors
 	State      State      // State of the Node
 	stateMutex sync.Mutex // mutex for change node state
 
@@ -195,6 +200,9 @@ type Node struct {
 	// Duplicated Ping Message Received
 	duplicatedPing sync.Map
 
+	// the leader's acknowledgement of the role we joined the shard with
+	joinAcks chan *proto_discovery.JoinAckMessageType
+
 	// Channel to notify consensus service to really start consensus
 	startConsensus chan struct{}
 
@@ -204,11 +212,20 @@ type Node struct {
 	// Chain configuration.
 	chainConfig params.ChainConfig
 
+	// genesis spec overriding the built-in genesis of the network type, if any
+	genesisSpec *core.GenesisSpec
+
 	// map of service type to its message channel.
 	serviceMessageChan map[service.Type]chan *msg_pb.Message
 
+	// routes the incoming p2p messages to their handlers
+	dispatcher *proto.Dispatcher
+
 	accountManager *accounts.Manager
 
+	// options of the network info service connecting to the bootnodes
+	BootnodeConnectOptions networkinfo.ConnectOptions
+
 	isFirstTime bool // the node was started with a fresh database
 	// How long in second the leader needs to wait to propose a new block.
 	BlockPeriod time.Duration
@@ -472,6 +489,9 @@ func New(
 	}{sync.Mutex{}, ring.New(sinkSize), ring.New(sinkSize)}
 	node.syncFreq = SyncFrequency
 	node.beaconSyncFreq = SyncFrequency
+	node.BootnodeConnectOptions = networkinfo.DefaultConnectOptions()
+	node.joinAcks = make(chan *proto_discovery.JoinAckMessageType, 1)
+	node.dispatcher = node.newMessageDispatcher()
 
 	// Get the node config that's created in the harmony.go program.
 	if consensusObj != nil {
@@ -488,6 +508,14 @@ func New(
 
 	networkType := node.NodeConfig.GetNetworkType()
 	chainConfig := networkType.ChainConfig()
+	if fileName := node.NodeConfig.GenesisFile; fileName != "" {
+		spec, err := core.ReadGenesisSpec(fileName)
+		if err != nil {
+			utils.FatalErrMsg(err, "cannot use genesis spec")
+		}
+		node.genesisSpec = spec
+		chainConfig = *spec.Config
+	}
 	node.chainConfig = chainConfig
 
 	collection := shardchain.NewCollection(
@@ -524,6 +552,9 @@ func New(
 		node.BeaconBlockChannel = make(chan *types.Block)
 		txPoolConfig := core.DefaultTxPoolConfig
 		txPoolConfig.Blacklist = blacklist
+		if price := node.NodeConfig.MinGasPrice; price != 0 {
+			txPoolConfig.PriceLimit = price
+		}
 		node.TxPool = core.NewTxPool(txPoolConfig, node.Blockchain().Config(), blockchain,
 			func(payload []types.RPCTransactionError) {
 				if len(payload) > 0 {
@@ -693,6 +724,8 @@ func (node *Node) InitConsensusWithValidators() (err error) {
 
 // AddPeers adds neighbors nodes
 func (node *Node) AddPeers(peers []*p2p.Peer) int {
+	node.peerMutex.Lock()
+	defer node.peerMutex.Unlock()
 	count := 0
 	for _, p := range peers {
 		key := fmt.Sprintf("%s:%s:%s", p.IP, p.Port, p.PeerID)
@@ -709,6 +742,26 @@ func (node *Node) AddPeers(peers []*p2p.Peer) int {
 	return count
 }
 
+// RemovePeer removes a neighbor node, returning whether it was known
+func (node *Node) RemovePeer(ip, port string, peerID libp2p_peer.ID) bool {
+	node.peerMutex.Lock()
+	defer node.peerMutex.Unlock()
+	key := fmt.Sprintf("%s:%s:%s", ip, port, peerID)
+	if _, ok := node.Neighbors.Load(key); !ok {
+		return false
+	}
+	node.Neighbors.Delete(key)
+	node.numPeers--
+	return true
+}
+
+// NumPeers returns the number of neighbor nodes
+func (node *Node) NumPeers() int {
+	node.peerMutex.Lock()
+	defer node.peerMutex.Unlock()
+	return node.numPeers
+}
+
 // AddBeaconPeer adds beacon chain neighbors nodes
 // Return false means new neighbor peer was added
 // Return true means redundant neighbor peer wasn't added
@@ -769,6 +822,12 @@ func (node *Node) ServiceManager() *service.Manager {
 	return node.serviceManager
 }
 
+// MessageDispatcher returns the dispatcher of the incoming p2p messages, to
+// register handlers of new message types or add middlewares.
+func (node *Node) MessageDispatcher() *proto.Dispatcher {
+	return node.dispatcher
+}
+
 // SetSyncFreq sets the syncing frequency in the loop
 func (node *Node) SetSyncFreq(syncFreq int) {
 	node.syncFreq = syncFreq
@@ -781,6 +840,7 @@ func (node *Node) SetBeaconSyncFreq(syncFreq int) {
 
 // ShutDown gracefully shut down the node server and dump the in-memory blockchain state into DB.
 func (node *Node) ShutDown() {
+	node.sendLeave()
 	node.Blockchain().Stop()
 	node.Beaconchain().Stop()
 	msg := "Successfully shut down!\n"
@@ -2,7 +2,6 @@ package node
 
 import (
 	"crypto/ecdsa"
-	"errors"
 	"math/big"
 	"math/rand"
 	"strings"
@@ -20,6 +19,7 @@ import (
 	"github.com/harmony-one/harmony/internal/utils"
 	"github.com/harmony-one/harmony/shard"
 	"github.com/harmony-one/harmony/shard/committee"
+	"github.com/pkg/errors"
 )
 
 const (
@@ -59,12 +59,11 @@ func (gi *genesisInitializer) InitChainDB(db ethdb.Database, shardID uint32) err
 		}
 		shardState = &shard.State{nil, []shard.Committee{*subComm}}
 	}
-	gi.node.SetupGenesisBlock(db, shardID, shardState)
-	return nil
+	return gi.node.SetupGenesisBlock(db, shardID, shardState)
 }
 
 // SetupGenesisBlock sets up a genesis blockchain.
-func (node *Node) SetupGenesisBlock(db ethdb.Database, shardID uint32, myShardState *shard.State) {
+func (node *Node) SetupGenesisBlock(db ethdb.Database, shardID uint32, myShardState *shard.State) error {
 	utils.Logger().Info().Interface("shardID", shardID).Msg("setting up a brand new chain database")
 	if shardID == node.NodeConfig.ShardID {
 		node.isFirstTime = true
@@ -113,6 +112,37 @@ func (node *Node) SetupGenesisBlock(db ethdb.Database, shardID uint32, myShardSt
 		node.ContractDeployerKey = contractDeployerKey
 	}
 
+	timestamp := uint64(1561734000) // GMT: Friday, June 28, 2019 3:00:00 PM. PST: Friday, June 28, 2019 8:00:00 AM
+	extraData := []byte("Harmony for One and All. Open Consensus for 10B.")
+
+	// A genesis spec overrides the chain config, gas limit, timestamp and
+	// extra data of the network type, and adds its allocations for this
+	// shard.  Its committees, if any, are in the sharding schedule already;
+	// see (*core.GenesisSpec).ShardingSchedule.
+	if spec := node.genesisSpec; spec != nil {
+		numShards := shard.Schedule.InstanceForEpoch(big.NewInt(core.GenesisEpoch)).NumShards()
+		for id := range spec.Alloc {
+			if id >= numShards {
+				return errors.Errorf("genesis spec allocates to shard %d of %d", id, numShards)
+			}
+		}
+		chainConfig = *spec.Config
+		for address, account := range spec.Alloc[shardID] {
+			genesisAlloc[address] = account
+		}
+		gasLimit = uint64(spec.GasLimit)
+		if spec.Timestamp != 0 {
+			timestamp = uint64(spec.Timestamp)
+		}
+		if spec.ExtraData != nil {
+			extraData = spec.ExtraData
+		}
+		utils.Logger().Info().
+			Uint64("chainID", chainConfig.ChainID.Uint64()).
+			Int("numAllocs", len(spec.Alloc[shardID])).
+			Msg("using genesis spec")
+	}
+
 	gspec := core.Genesis{
 		Config:         &chainConfig,
 		Factory:        blockfactory.NewFactory(&chainConfig),
@@ -121,12 +151,13 @@ func (node *Node) SetupGenesisBlock(db ethdb.Database, shardID uint32, myShardSt
 		GasLimit:       gasLimit,
 		ShardStateHash: myShardState.Hash(),
 		ShardState:     *myShardState.DeepCopy(),
-		Timestamp:      1561734000, // GMT: Friday, June 28, 2019 3:00:00 PM. PST: Friday, June 28, 2019 8:00:00 AM
-		ExtraData:      []byte("Harmony for One and All. Open Consensus for 10B."),
+		Timestamp:      timestamp,
+		ExtraData:      extraData,
 	}
 
 	// Store genesis block into db.
-	gspec.MustCommit(db)
+	_, err := gspec.Commit(db)
+	return err
 }
 
 // CreateTestBankKeys deterministically generates testing addresses.
@@ -29,10 +29,9 @@ import (
 	staking "github.com/harmony-one/harmony/staking/types"
 	"github.com/harmony-one/harmony/webhooks"
 	libp2p_peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
 )
 
-const p2pMsgPrefixSize = 5
-
 // receiveGroupMessage use libp2p pubsub mechanism to receive broadcast messages
 func (node *Node) receiveGroupMessage(
 	receiver p2p.GroupReceiver, rxQueue msgq.MessageAdder,
@@ -50,13 +49,14 @@ func (node *Node) receiveGroupMessage(
 			continue
 		}
 		//utils.Logger().Info("[PUBSUB]", "received group msg", len(msg), "sender", sender)
-		// skip the first 5 bytes, 1 byte is p2p type, 4 bytes are message size
-		if len(msg) < p2pMsgPrefixSize {
+		content, err := host.ParseP2pMessage(msg)
+		if err != nil {
 			utils.Logger().Warn().Err(err).Int("msg size", len(msg)).
-				Msg("invalid p2p message size")
+				Str("sender", sender.Pretty()).
+				Msg("invalid p2p message")
 			continue
 		}
-		if err := rxQueue.AddMessage(msg[p2pMsgPrefixSize:], sender); err != nil {
+		if err := rxQueue.AddMessage(content, sender); err != nil {
 			utils.Logger().Warn().Err(err).
 				Str("sender", sender.Pretty()).
 				Msg("cannot enqueue incoming message for processing")
@@ -92,102 +92,125 @@ func (node *Node) processSkippedMsgTypeByteValue(cat proto_node.BlockMessageType
 
 // HandleMessage parses the message and dispatch the actions.
 func (node *Node) HandleMessage(content []byte, sender libp2p_peer.ID) {
-	msgCategory, err := proto.GetMessageCategory(content)
-	if err != nil {
-		utils.Logger().Error().
+	err := node.dispatcher.Dispatch(content, sender)
+	if errors.Cause(err) == proto.ErrNoHandler {
+		// e.g. a deprecated message type from an older node
+		utils.Logger().Debug().
 			Err(err).
-			Msg("HandleMessage get message category failed")
-		return
-	}
-	msgType, err := proto.GetMessageType(content)
-	if err != nil {
+			Str("sender", sender.Pretty()).
+			Msg("HandleMessage ignored message")
+	} else if err != nil {
 		utils.Logger().Error().
 			Err(err).
-			Msg("HandleMessage get message type failed")
-		return
+			Str("sender", sender.Pretty()).
+			Msg("HandleMessage cannot dispatch message")
 	}
+}
 
-	msgPayload, err := proto.GetMessagePayload(content)
-	if err != nil {
-		utils.Logger().Error().
-			Err(err).
-			Msg("HandleMessage get message payload failed")
-		return
+// newMessageDispatcher returns a dispatcher with the handlers of the node
+// messages registered.
+func (node *Node) newMessageDispatcher() *proto.Dispatcher {
+	d := proto.NewDispatcher()
+	mustRegister := func(err error) {
+		if err != nil {
+			panic(err) // only on a duplicate registration below
+		}
 	}
-
-	switch msgCategory {
-	case proto.Consensus:
-		msgPayload, _ := proto.GetConsensusMessagePayload(content)
+	mustRegister(d.RegisterCategory(proto.Consensus, func(msg *proto.IncomingMessage) {
 		if node.NodeConfig.Role() == nodeconfig.ExplorerNode {
-			node.ExplorerMessageHandler(msgPayload)
+			node.ExplorerMessageHandler(msg.Payload)
 		} else {
-			node.ConsensusMessageHandler(msgPayload)
+			node.ConsensusMessageHandler(msg.Payload)
 		}
-	case proto.DRand:
-		msgPayload, _ := proto.GetDRandMessagePayload(content)
+	}))
+	mustRegister(d.RegisterCategory(proto.DRand, func(msg *proto.IncomingMessage) {
 		if node.DRand != nil {
 			if node.DRand.IsLeader {
-				node.DRand.ProcessMessageLeader(msgPayload)
+				node.DRand.ProcessMessageLeader(msg.Payload)
 			} else {
-				node.DRand.ProcessMessageValidator(msgPayload)
+				node.DRand.ProcessMessageValidator(msg.Payload)
 			}
 		}
-	case proto.Node:
-		actionType := proto_node.MessageType(msgType)
-		switch actionType {
-		case proto_node.Transaction:
+	}))
+	nodeHandlers := map[proto_node.MessageType]proto.MessageHandler{
+		proto_node.Transaction: func(msg *proto.IncomingMessage) {
 			utils.Logger().Debug().Msg("NET: received message: Node/Transaction")
-			node.transactionMessageHandler(msgPayload)
-		case proto_node.Staking:
+			node.transactionMessageHandler(msg.Payload)
+		},
+		proto_node.Staking: func(msg *proto.IncomingMessage) {
 			utils.Logger().Debug().Msg("NET: received message: Node/Staking")
-			node.stakingMessageHandler(msgPayload)
-		case proto_node.Block:
+			node.stakingMessageHandler(msg.Payload)
+		},
+		proto_node.Block: func(msg *proto.IncomingMessage) {
 			utils.Logger().Debug().Msg("NET: received message: Node/Block")
-			if len(msgPayload) < 1 {
-				utils.Logger().Debug().Msgf("Invalid block message size")
-				return
-			}
+			node.blockMessageHandler(msg.Payload)
+		},
+		proto_node.PING: func(msg *proto.IncomingMessage) {
+			node.pingMessageHandler(msg.Payload, msg.Sender)
+		},
+		proto_node.JoinAck: func(msg *proto.IncomingMessage) {
+			node.joinAckMessageHandler(msg.Payload, msg.Sender)
+		},
+		proto_node.Leave: func(msg *proto.IncomingMessage) {
+			node.leaveMessageHandler(msg.Payload, msg.Sender)
+		},
+	}
+	for msgType, handler := range nodeHandlers {
+		mustRegister(d.Register(proto.Node, byte(msgType), handler))
+	}
+	return d
+}
 
-			switch blockMsgType := proto_node.BlockMessageType(msgPayload[0]); blockMsgType {
-			case proto_node.Sync:
-				utils.Logger().Debug().Msg("NET: received message: Node/Sync")
-				var blocks []*types.Block
-				err := rlp.DecodeBytes(msgPayload[1:], &blocks)
-				if err != nil {
-					utils.Logger().Error().
-						Err(err).
-						Msg("block sync")
-				} else {
-					// for non-beaconchain node, subscribe to beacon block broadcast
-					if node.Blockchain().ShardID() != shard.BeaconChainShardID &&
-						node.NodeConfig.Role() != nodeconfig.ExplorerNode {
-						for _, block := range blocks {
-							if block.ShardID() == 0 {
-								utils.Logger().Info().
-									Uint64("block", blocks[0].NumberU64()).
-									Msgf("Beacon block being handled by block channel: %d", block.NumberU64())
-								node.BeaconBlockChannel <- block
-							}
-						}
-					}
-					if node.Client != nil && node.Client.UpdateBlocks != nil && blocks != nil {
-						utils.Logger().Info().Msg("Block being handled by client")
-						node.Client.UpdateBlocks(blocks)
+func (node *Node) blockMessageHandler(msgPayload []byte) {
+	if len(msgPayload) < 1 {
+		utils.Logger().Debug().Msgf("Invalid block message size")
+		return
+	}
+
+	switch blockMsgType := proto_node.BlockMessageType(msgPayload[0]); blockMsgType {
+	case proto_node.Sync:
+		utils.Logger().Debug().Msg("NET: received message: Node/Sync")
+		var blocks []*types.Block
+		err := rlp.DecodeBytes(msgPayload[1:], &blocks)
+		if err != nil {
+			utils.Logger().Error().
+				Err(err).
+				Msg("block sync")
+		} else {
+			// for non-beaconchain node, subscribe to beacon block broadcast
+			if node.Blockchain().ShardID() != shard.BeaconChainShardID &&
+				node.NodeConfig.Role() != nodeconfig.ExplorerNode {
+				for _, block := range blocks {
+					if block.ShardID() == 0 {
+						utils.Logger().Info().
+							Uint64("block", blocks[0].NumberU64()).
+							Msgf("Beacon block being handled by block channel: %d", block.NumberU64())
+						node.BeaconBlockChannel <- block
 					}
 				}
-			case
-				proto_node.SlashCandidate,
-				proto_node.Receipt,
-				proto_node.CrossLink:
-				// skip first byte which is blockMsgType
-				node.processSkippedMsgTypeByteValue(blockMsgType, msgPayload[1:])
 			}
-		case proto_node.PING:
-			node.pingMessageHandler(msgPayload, sender)
+			if node.Client != nil && node.Client.UpdateBlocks != nil && blocks != nil {
+				utils.Logger().Info().Msg("Block being handled by client")
+				node.Client.UpdateBlocks(blocks)
+			}
 		}
-	default:
-		utils.Logger().Error().
-			Str("Unknown MsgCateogry", string(msgCategory))
+	case proto_node.SyncWithSig:
+		utils.Logger().Debug().Msg("NET: received message: Node/SyncWithSig")
+		blocks, err := proto_node.DeserializeBlocksSyncWithSigMessage(msgPayload[1:])
+		if err != nil {
+			utils.Logger().Error().
+				Err(err).
+				Msg("block sync with signatures")
+		} else if node.Client != nil && node.Client.UpdateBlocksWithSig != nil {
+			utils.Logger().Info().Msg("Block with signature being handled by client")
+			node.Client.UpdateBlocksWithSig(blocks)
+		}
+	case
+		proto_node.SlashCandidate,
+		proto_node.Receipt,
+		proto_node.CrossLink:
+		// skip first byte which is blockMsgType
+		node.processSkippedMsgTypeByteValue(blockMsgType, msgPayload[1:])
 	}
 }
 
@@ -250,11 +273,14 @@ func (node *Node) BroadcastNewBlock(newBlock *types.Block) {
 		Msgf(
 			"broadcasting new block %d, group %s", newBlock.NumberU64(), groups[0],
 		)
-	msg := host.ConstructP2pMessage(byte(0),
+	for _, payload := range [][]byte{
 		proto_node.ConstructBlocksSyncMessage([]*types.Block{newBlock}),
-	)
-	if err := node.host.SendMessageToGroups(groups, msg); err != nil {
-		utils.Logger().Warn().Err(err).Msg("cannot broadcast new block")
+		proto_node.ConstructBlocksSyncWithSigMessage([]*types.Block{newBlock}),
+	} {
+		msg := host.ConstructP2pMessage(byte(0), payload)
+		if err := node.host.SendMessageToGroups(groups, msg); err != nil {
+			utils.Logger().Warn().Err(err).Msg("cannot broadcast new block")
+		}
 	}
 }
 
@@ -444,6 +470,9 @@ func (node *Node) PostConsensusProcessing(
 		Str("hash", newBlock.Header().Hash().Hex()).
 		Msg("Added New Block to Blockchain!!!")
 
+	// Clients verify broadcast blocks against it
+	newBlock.SetCurrentCommitSig(commitSigAndBitmap)
+
 	// Update last consensus time for metrics
 	// TODO: randomly selected a few validators to broadcast messages instead of only leader broadcast
 	// TODO: refactor the asynchronous calls to separate go routine.
@@ -557,30 +586,172 @@ func (node *Node) pingMessageHandler(msgPayload []byte, sender libp2p_peer.ID) i
 		}
 	}
 
+	// acknowledge the first ping of every peer only, so that pings are not
+	// answered with as many broadcasts
+	if node.Consensus != nil && node.Consensus.IsLeader() {
+		node.sendJoinAck(ping)
+	}
+
 	// add to incoming peer list
 	//node.host.AddIncomingPeer(*peer)
 	node.host.ConnectHostPeer(*peer)
 
-	if ping.Node.Role != proto_node.ClientRole {
+	// only validators take part in consensus; clients and explorers
+	// are just connected to
+	if ping.Node.Role == proto_node.ValidatorRole {
 		node.AddPeers([]*p2p.Peer{peer})
 		utils.Logger().Info().
 			Str("Peer", peer.String()).
-			Int("# Peers", node.numPeers).
+			Int("# Peers", node.NumPeers()).
 			Msg("Add Peer to Node")
 	}
 
 	return 1
 }
 
+// sendJoinAck acknowledges the role the pinging node has joined the shard
+// with.  Clients listen on the client group of the shard, everyone else on the
+// shard group.
+func (node *Node) sendJoinAck(ping *proto_discovery.PingMessageType) {
+	group := node.NodeConfig.GetShardGroupID()
+	if ping.Node.Role == proto_node.ClientRole {
+		group = node.NodeConfig.GetClientGroupID()
+	}
+	ack := proto_discovery.NewJoinAckMessage(node.NodeConfig.ShardID, ping)
+	if err := node.host.SendMessageToGroups(
+		[]nodeconfig.GroupID{group},
+		host.ConstructP2pMessage(byte(0), ack.ConstructJoinAckMessage()),
+	); err != nil {
+		utils.Logger().Warn().
+			Err(err).
+			Str("group", string(group)).
+			Str("ack", ack.String()).
+			Msg("[PING] cannot send join ack")
+	}
+}
+
+// joinAckMessageHandler handles the leader's acknowledgement of our own ping.
+// The sender must be the peer which announced the current leader's key.
+func (node *Node) joinAckMessageHandler(msgPayload []byte, sender libp2p_peer.ID) {
+	ack, err := proto_discovery.GetJoinAckMessage(msgPayload)
+	if err != nil {
+		utils.Logger().Error().
+			Err(err).
+			Msg("Can't get JoinAck Message")
+		return
+	}
+	if ack.Node.PeerID != node.host.GetID() {
+		// acknowledgement for another node sharing the group
+		return
+	}
+	if !node.isLeaderPeer(sender) {
+		utils.Logger().Warn().
+			Interface("sender", sender).
+			Msg("[PING] join ack not sent by the leader")
+		return
+	}
+	utils.Logger().Info().
+		Uint32("ShardID", ack.ShardID).
+		Str("Role", ack.Node.Role.String()).
+		Msg("[PING] joined shard")
+	select {
+	case node.joinAcks <- ack:
+	default:
+		// an earlier acknowledgement is still pending
+	}
+}
+
+// WaitForJoinAck waits for the shard leader to acknowledge the role this node
+// joined the shard with, and returns the acknowledgement.
+func (node *Node) WaitForJoinAck(timeout time.Duration) (*proto_discovery.JoinAckMessageType, error) {
+	select {
+	case ack := <-node.joinAcks:
+		return ack, nil
+	case <-time.After(timeout):
+		return nil, errors.Errorf("no join acknowledgement within %v", timeout)
+	}
+}
+
+// isLeaderPeer tells whether the peer is the neighbor which announced the
+// key of the current leader in its ping.  Nodes not taking part in consensus,
+// such as clients, do not know the leader, and take any committee member for
+// it.
+func (node *Node) isLeaderPeer(peerID libp2p_peer.ID) bool {
+	if peerID == "" || node.Consensus == nil {
+		return false
+	}
+	leaderKey := node.Consensus.LeaderPubKey()
+	isLeader := false
+	node.Neighbors.Range(func(k, v interface{}) bool {
+		if p, ok := v.(p2p.Peer); ok && p.PeerID == peerID && p.ConsensusPubKey != nil {
+			if leaderKey != nil {
+				isLeader = p.ConsensusPubKey.IsEqual(leaderKey)
+			} else {
+				isLeader = node.Consensus.Decider.IndexOf(p.ConsensusPubKey) >= 0
+			}
+		}
+		return !isLeader
+	})
+	return isLeader
+}
+
+// leaveMessageHandler drops a peer that announced its clean shutdown, so that
+// it is not counted or synced from for the rest of the epoch.
+func (node *Node) leaveMessageHandler(msgPayload []byte, sender libp2p_peer.ID) {
+	leave, err := proto_discovery.GetPingMessage(msgPayload)
+	if err != nil {
+		utils.Logger().Error().
+			Err(err).
+			Msg("Can't get Leave Message")
+		return
+	}
+	if sender != "" && leave.Node.PeerID != sender {
+		// only a node itself may announce its departure
+		utils.Logger().Warn().
+			Interface("PeerID", leave.Node.PeerID).
+			Interface("sender", sender).
+			Msg("[LEAVE] sender mismatch")
+		return
+	}
+	if node.RemovePeer(leave.Node.IP, leave.Node.Port, leave.Node.PeerID) {
+		utils.Logger().Info().
+			Str("IP", leave.Node.IP).
+			Str("Port", leave.Node.Port).
+			Int("# Peers", node.NumPeers()).
+			Msg("[LEAVE] Remove Peer from Node")
+	}
+	node.duplicatedPing.Delete(string(leave.Node.PeerID))
+}
+
+// sendLeave tells the shard we are going away.
+func (node *Node) sendLeave() {
+	role := proto_node.ValidatorRole
+	switch {
+	case node.NodeConfig.IsClient():
+		role = proto_node.ClientRole
+	case node.NodeConfig.Role() == nodeconfig.ExplorerNode:
+		role = proto_node.ExplorerRole
+	}
+	leave := proto_discovery.NewPingMessage(node.SelfPeer, role)
+	if err := node.host.SendMessageToGroups(
+		[]nodeconfig.GroupID{node.NodeConfig.GetShardGroupID()},
+		host.ConstructP2pMessage(byte(0), leave.ConstructLeaveMessage()),
+	); err != nil {
+		utils.Logger().Warn().
+			Err(err).
+			Msg("[LEAVE] cannot send leave message")
+	}
+}
+
 // bootstrapConsensus is the a goroutine to check number of peers and start the consensus
 func (node *Node) bootstrapConsensus() {
 	tick := time.NewTicker(5 * time.Second)
 	defer tick.Stop()
-	lastPeerNum := node.numPeers
+	lastPeerNum := node.NumPeers()
 	for {
 		select {
 		case <-tick.C:
-			numPeersNow := node.numPeers
+			numPeersNow := node.NumPeers()
 			// no peers, wait for another tick
 			if numPeersNow == 0 {
 				utils.Logger().Info().
@@ -26,7 +26,7 @@ func (node *Node) UpdateBlockHeightForMetrics(prevBlockHeight uint64) uint64 {
 
 // UpdateConnectionsNumberForMetrics uppdates connections number for metrics service.
 func (node *Node) UpdateConnectionsNumberForMetrics(prevNumPeers int) int {
-	curNumPeers := node.numPeers
+	curNumPeers := node.NumPeers()
 	if curNumPeers == prevNumPeers {
 		return prevNumPeers
 	}
@@ -66,7 +66,7 @@ func (node *Node) UpdateLastConsensusTimeForMetrics(prevLastConsensusTime int64)
 
 // UpdateIsLeaderForMetrics updates if node is a leader now for metrics serivce.
 func (node *Node) UpdateIsLeaderForMetrics() {
-	if node.Consensus.LeaderPubKey.SerializeToHexStr() == node.Consensus.PubKey.SerializeToHexStr() {
+	if node.Consensus.LeaderPubKey().SerializeToHexStr() == node.Consensus.PubKey.SerializeToHexStr() {
 		utils.Logger().Info().Msgf("Node %s is a leader now", node.Consensus.PubKey.SerializeToHexStr())
 		metrics.UpdateIsLeader(true)
 	} else {
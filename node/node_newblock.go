@@ -94,7 +94,7 @@ func (node *Node) proposeNewBlock() (*types.Block, error) {
 	// Update worker's current header and
 	// state data in preparation to propose/process new transactions
 	var (
-		coinbase    = node.Consensus.SelfAddresses[node.Consensus.LeaderPubKey.SerializeToHexStr()]
+		coinbase    = node.Consensus.SelfAddresses[node.Consensus.LeaderPubKey().SerializeToHexStr()]
 		beneficiary = coinbase
 		err         error
 	)
@@ -102,7 +102,7 @@ func (node *Node) proposeNewBlock() (*types.Block, error) {
 	header := node.Worker.GetCurrentHeader()
 	// After staking, all coinbase will be the address of bls pub key
 	if node.Blockchain().Config().IsStaking(header.Epoch()) {
-		blsPubKeyBytes := node.Consensus.LeaderPubKey.GetAddress()
+		blsPubKeyBytes := node.Consensus.LeaderPubKey().GetAddress()
 		coinbase.SetBytes(blsPubKeyBytes[:])
 	}
 
@@ -158,6 +158,50 @@ func (p *LocalSyncingPeerProvider) SyncingPeers(shardID uint32) (peers []p2p.Pee
 	return peers, nil
 }
 
+// FallbackSyncingPeerProvider queries a list of syncing peer providers in
+// order and returns the peers from the first one that yields any, so that a
+// single unreachable source does not leave the node without syncing peers.
+type FallbackSyncingPeerProvider struct {
+	providers []SyncingPeerProvider
+}
+
+// NewFallbackSyncingPeerProvider returns a provider that fails over between
+// the given providers, in the given order.
+func NewFallbackSyncingPeerProvider(
+	providers ...SyncingPeerProvider,
+) *FallbackSyncingPeerProvider {
+	return &FallbackSyncingPeerProvider{providers: providers}
+}
+
+// SyncingPeers returns the non-empty peer list of the first provider that
+// succeeds, or an error if none of the providers returned any peer.
+func (p *FallbackSyncingPeerProvider) SyncingPeers(shardID uint32) (peers []p2p.Peer, err error) {
+	var lastErr error
+	for i, provider := range p.providers {
+		peers, err := provider.SyncingPeers(shardID)
+		if err != nil {
+			utils.Logger().Warn().
+				Err(err).
+				Int("provider", i).
+				Uint32("shardID", shardID).
+				Msg("[SYNC] syncing peer provider failed, trying next one")
+			lastErr = err
+			continue
+		}
+		if len(peers) > 0 {
+			return peers, nil
+		}
+	}
+	if lastErr != nil {
+		return nil, errors.Wrapf(lastErr,
+			"no syncing peers for shard %d from %d providers",
+			shardID, len(p.providers))
+	}
+	return nil, errors.Errorf(
+		"no syncing peers for shard %d from %d providers",
+		shardID, len(p.providers))
+}
+
 // DoBeaconSyncing update received beaconchain blocks and downloads missing beacon chain blocks
 func (node *Node) DoBeaconSyncing() {
 	go func(node *Node) {
@@ -189,10 +233,12 @@ func (node *Node) DoBeaconSyncing() {
 				utils.Logger().Warn().
 					Err(err).
 					Msg("cannot retrieve beacon syncing peers")
+				time.Sleep(time.Duration(node.beaconSyncFreq) * time.Second)
 				continue
 			}
 			if err := node.beaconSync.CreateSyncConfig(peers, true); err != nil {
 				utils.Logger().Warn().Err(err).Msg("cannot create beacon sync config")
+				time.Sleep(time.Duration(node.beaconSyncFreq) * time.Second)
 				continue
 			}
 		}
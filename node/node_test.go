@@ -3,23 +3,30 @@ package node
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"math/big"
 	"os"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/bls/ffi/go/bls"
 	proto_discovery "github.com/harmony-one/harmony/api/proto/discovery"
+	proto_node "github.com/harmony-one/harmony/api/proto/node"
 	"github.com/harmony-one/harmony/consensus"
 	"github.com/harmony-one/harmony/consensus/quorum"
 	bls2 "github.com/harmony-one/harmony/crypto/bls"
 	"github.com/harmony-one/harmony/crypto/pki"
 	"github.com/harmony-one/harmony/drand"
+	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
 	"github.com/harmony-one/harmony/internal/shardchain"
 	"github.com/harmony-one/harmony/internal/utils"
 	"github.com/harmony-one/harmony/multibls"
 	"github.com/harmony-one/harmony/p2p"
 	"github.com/harmony-one/harmony/p2p/p2pimpl"
 	"github.com/harmony-one/harmony/shard"
+	libp2p_peer "github.com/libp2p/go-libp2p-core/peer"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -178,6 +185,49 @@ func makeLocalSyncingPeerProvider() *LocalSyncingPeerProvider {
 	return NewLocalSyncingPeerProvider(6000, 6001, 2, 3)
 }
 
+type fakeSyncingPeerProvider struct {
+	peers []p2p.Peer
+	err   error
+	calls int
+}
+
+func (p *fakeSyncingPeerProvider) SyncingPeers(shardID uint32) ([]p2p.Peer, error) {
+	p.calls++
+	return p.peers, p.err
+}
+
+func TestFallbackSyncingPeerProvider(t *testing.T) {
+	peers := []p2p.Peer{{IP: "1.2.3.4", Port: "1234"}}
+	t.Run("FirstSucceeds", func(t *testing.T) {
+		first := &fakeSyncingPeerProvider{peers: peers}
+		second := &fakeSyncingPeerProvider{}
+		p := NewFallbackSyncingPeerProvider(first, second)
+		if actualPeers, err := p.SyncingPeers(0); assert.NoError(t, err) {
+			assert.Equal(t, peers, actualPeers)
+		}
+		assert.Equal(t, 0, second.calls)
+	})
+	t.Run("FailOver", func(t *testing.T) {
+		failing := &fakeSyncingPeerProvider{err: errors.New("omg")}
+		empty := &fakeSyncingPeerProvider{}
+		working := &fakeSyncingPeerProvider{peers: peers}
+		p := NewFallbackSyncingPeerProvider(failing, empty, working)
+		if actualPeers, err := p.SyncingPeers(0); assert.NoError(t, err) {
+			assert.Equal(t, peers, actualPeers)
+		}
+		assert.Equal(t, 1, failing.calls)
+		assert.Equal(t, 1, empty.calls)
+	})
+	t.Run("AllFail", func(t *testing.T) {
+		p := NewFallbackSyncingPeerProvider(
+			&fakeSyncingPeerProvider{err: errors.New("omg")},
+			&fakeSyncingPeerProvider{},
+		)
+		_, err := p.SyncingPeers(0)
+		assert.Error(t, err)
+	})
+}
+
 func TestAddPeers(t *testing.T) {
 	pubKey1 := pki.GetBLSPrivateKeyFromInt(333).GetPublicKey()
 	pubKey2 := pki.GetBLSPrivateKeyFromInt(444).GetPublicKey()
@@ -226,6 +276,104 @@ func TestAddPeers(t *testing.T) {
 	if r2 != e2 {
 		t.Errorf("Add %v peers, expectd %v", r2, e2)
 	}
+	if !node.RemovePeer("127.0.0.1", "8888", "") {
+		t.Error("expected known peer to be removed")
+	}
+	if node.RemovePeer("127.0.0.1", "8888", "") {
+		t.Error("expected removed peer to be unknown")
+	}
+	if node.NumPeers() != 1 {
+		t.Errorf("expected 1 peer left, got %v", node.NumPeers())
+	}
+	r3 := node.AddPeers(peers1)
+	e3 := 1
+	if r3 != e3 {
+		t.Errorf("Add %v peers, expectd %v", r3, e3)
+	}
+
+}
+
+// newTestNode returns a beacon chain node led by itself, listening on the port
+func newTestNode(t *testing.T, port string) *Node {
+	blsKey := bls2.RandPrivateKey()
+	leader := p2p.Peer{IP: "127.0.0.1", Port: port, ConsensusPubKey: blsKey.GetPublicKey()}
+	priKey, _, _ := utils.GenKeyP2P("127.0.0.1", port)
+	host, err := p2pimpl.NewHost(&leader, priKey)
+	if err != nil {
+		t.Fatalf("newhost failure: %v", err)
+	}
+	decider := quorum.NewDecider(
+		quorum.SuperMajorityVote, shard.BeaconChainShardID,
+	)
+	consensus, err := consensus.New(
+		host, shard.BeaconChainShardID, leader, multibls.GetPrivateKey(blsKey), decider,
+	)
+	if err != nil {
+		t.Fatalf("Cannot craeate consensus: %v", err)
+	}
+	return New(host, consensus, testDBFactory, nil, false)
+}
+
+func TestRemovePeerConcurrently(t *testing.T) {
+	node := newTestNode(t, "9905")
+	peer := &p2p.Peer{IP: "127.0.0.1", Port: "7777", ConsensusPubKey: pki.GetBLSPrivateKeyFromInt(333).GetPublicKey()}
+	node.AddPeers([]*p2p.Peer{peer})
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			node.RemovePeer(peer.IP, peer.Port, peer.PeerID)
+		}()
+	}
+	wg.Wait()
+	if node.NumPeers() != 0 {
+		t.Errorf("expected no peer left, got %v", node.NumPeers())
+	}
+}
+
+func TestIsLeaderPeer(t *testing.T) {
+	node := newTestNode(t, "9903")
+	pubKey1 := pki.GetBLSPrivateKeyFromInt(333).GetPublicKey()
+	pubKey2 := pki.GetBLSPrivateKeyFromInt(444).GetPublicKey()
+	leaderPeerID, _ := libp2p_peer.IDB58Decode("QmVdSe4RdeC3xPemFuRG9Mhzo28EXbiBZh1tpjsuMbHqh1")
+	node.AddPeers([]*p2p.Peer{{IP: "127.0.0.1", Port: "7777", PeerID: leaderPeerID, ConsensusPubKey: pubKey2}})
+	node.Consensus.SetLeaderPubKey(pubKey1)
+	if node.isLeaderPeer(leaderPeerID) {
+		t.Error("peer of another key taken for the leader")
+	}
+	node.Consensus.SetLeaderPubKey(pubKey2)
+	if !node.isLeaderPeer(leaderPeerID) || node.isLeaderPeer("") {
+		t.Error("wrong leader peer")
+	}
+}
+
+func TestWaitForJoinAck(t *testing.T) {
+	node := newTestNode(t, "9904")
+	leaderKey := pki.GetBLSPrivateKeyFromInt(333).GetPublicKey()
+	leaderPeerID, _ := libp2p_peer.IDB58Decode("QmVdSe4RdeC3xPemFuRG9Mhzo28EXbiBZh1tpjsuMbHqh1")
+	node.AddPeers([]*p2p.Peer{{IP: "127.0.0.1", Port: "7777", PeerID: leaderPeerID, ConsensusPubKey: leaderKey}})
+	// as on clients, the leader is not known, but the committee is
+	node.Consensus.SetLeaderPubKey(nil)
+	node.Consensus.Decider.UpdateParticipants([]*bls.PublicKey{leaderKey})
+
+	self := node.SelfPeer
+	self.PeerID = node.host.GetID()
+	ack := proto_discovery.NewJoinAckMessage(0, proto_discovery.NewPingMessage(self, proto_node.ClientRole))
+	payload := ack.ConstructJoinAckMessage()[2:] // skip the message category and type
+
+	node.joinAckMessageHandler(payload, "")
+	if _, err := node.WaitForJoinAck(10 * time.Millisecond); err == nil {
+		t.Error("accepted join ack of unknown sender")
+	}
+	node.joinAckMessageHandler(payload, leaderPeerID)
+	got, err := node.WaitForJoinAck(time.Second)
+	if err != nil {
+		t.Fatalf("WaitForJoinAck() failed: %s", err)
+	}
+	if got.Node.Role != proto_node.ClientRole || got.Node.PeerID != self.PeerID {
+		t.Errorf("unexpected join ack %s", got)
+	}
 }
 
 func TestAddBeaconPeer(t *testing.T) {
@@ -291,8 +439,8 @@ func sendPingMessage(node *Node, leader p2p.Peer) {
 		ConsensusPubKey: pubKey1,
 	}
 
-	ping1 := proto_discovery.NewPingMessage(p1, true)
-	ping2 := proto_discovery.NewPingMessage(p1, false)
+	ping1 := proto_discovery.NewPingMessage(p1, proto_node.ClientRole)
+	ping2 := proto_discovery.NewPingMessage(p1, proto_node.ValidatorRole)
 	_ = ping1.ConstructPingMessage()
 	_ = ping2.ConstructPingMessage()
 }
@@ -303,3 +451,40 @@ func exitServer() {
 
 	os.Exit(0)
 }
+
+func TestGenesisSpec(t *testing.T) {
+	f, err := ioutil.TempFile("", "genesis")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString(`{
+		"config": {"chain-id": 1234},
+		"gasLimit": "0x4c4b400",
+		"alloc": {
+			"0": {"b7a2c103728b7305b5ae6e961c94ee99c9fe8e2b": {"balance": "0x64"}},
+			"1": {"0d9b2ee4e2e4ed3b6e3c8a1e3f7c1a9c0a5b5e1f": {"balance": "0x64"}}
+		}
+	}`); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	nodeConfig := nodeconfig.GetShardConfig(shard.BeaconChainShardID)
+	nodeConfig.GenesisFile = f.Name()
+	defer func() { nodeConfig.GenesisFile = "" }()
+
+	node := newTestNode(t, "9906")
+	if chainID := node.Blockchain().Config().ChainID; chainID.Cmp(big.NewInt(1234)) != 0 {
+		t.Errorf("unexpected chain ID %v", chainID)
+	}
+	state, err := node.Blockchain().State()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance := state.GetBalance(common.HexToAddress("0xb7a2c103728b7305b5ae6e961c94ee99c9fe8e2b")); balance.Cmp(big.NewInt(100)) != 0 {
+		t.Errorf("unexpected balance %v of shard 0 allocation", balance)
+	}
+	if balance := state.GetBalance(common.HexToAddress("0x0d9b2ee4e2e4ed3b6e3c8a1e3f7c1a9c0a5b5e1f")); balance.Sign() != 0 {
+		t.Errorf("unexpected balance %v of shard 1 allocation in shard 0", balance)
+	}
+}
@@ -14,15 +14,21 @@ import (
 	"github.com/harmony-one/harmony/api/service/networkinfo"
 	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
 	"github.com/harmony-one/harmony/internal/utils"
+	"github.com/harmony-one/harmony/p2p"
+	"github.com/pkg/errors"
 )
 
-func (node *Node) setupForValidator() {
+func (node *Node) setupForValidator() error {
 	nodeConfig, chanPeer := node.initNodeConfiguration()
 
 	// Register peer discovery service. No need to do staking for beacon chain node.
 	node.serviceManager.RegisterService(service.PeerDiscovery, discovery.New(node.host, nodeConfig, chanPeer, node.AddBeaconPeer))
 	// Register networkinfo service. "0" is the beacon shard ID
-	node.serviceManager.RegisterService(service.NetworkInfo, networkinfo.MustNew(node.host, node.NodeConfig.GetShardGroupID(), chanPeer, nil, node.networkInfoDHTPath()))
+	networkInfo, err := node.newNetworkInfo(node.NodeConfig.GetShardGroupID(), chanPeer, node.networkInfoDHTPath())
+	if err != nil {
+		return err
+	}
+	node.serviceManager.RegisterService(service.NetworkInfo, networkInfo)
 	// Register consensus service.
 	node.serviceManager.RegisterService(service.Consensus, consensus.New(node.BlockChannel, node.Consensus, node.startConsensus))
 	// Register new block service.
@@ -43,52 +49,102 @@ func (node *Node) setupForValidator() {
 	// Need Dynamically enable for beacon validators
 	// node.serviceManager.RegisterService(service.Randomness, randomness.New(node.DRand))
 
+	return nil
 }
 
-func (node *Node) setupForNewNode() {
+func (node *Node) setupForNewNode() error {
 	// TODO determine the role of new node, currently assume it is beacon node
 	nodeConfig, chanPeer := node.initNodeConfiguration()
 
 	// Register peer discovery service. "0" is the beacon shard ID
 	node.serviceManager.RegisterService(service.PeerDiscovery, discovery.New(node.host, nodeConfig, chanPeer, node.AddBeaconPeer))
 	// Register networkinfo service. "0" is the beacon shard ID
-	node.serviceManager.RegisterService(service.NetworkInfo, networkinfo.MustNew(node.host, node.NodeConfig.GetBeaconGroupID(), chanPeer, nil, node.networkInfoDHTPath()))
+	networkInfo, err := node.newNetworkInfo(node.NodeConfig.GetBeaconGroupID(), chanPeer, node.networkInfoDHTPath())
+	if err != nil {
+		return err
+	}
+	node.serviceManager.RegisterService(service.NetworkInfo, networkInfo)
 	// Register new metrics service
 	if node.NodeConfig.GetMetricsFlag() {
 		node.serviceManager.RegisterService(service.Metrics, metrics.New(&node.SelfPeer, node.NodeConfig.ConsensusPubKey.SerializeToHexStr(), node.NodeConfig.GetPushgatewayIP(), node.NodeConfig.GetPushgatewayPort()))
 	}
+	return nil
 }
 
-func (node *Node) setupForClientNode() {
+func (node *Node) setupForClientNode() error {
+	nodeConfig, chanPeer := node.initNodeConfiguration()
+
+	// Register peer discovery service, which joins the shard as a client.
+	node.serviceManager.RegisterService(service.PeerDiscovery, discovery.New(node.host, nodeConfig, chanPeer, nil))
 	// Register networkinfo service. "0" is the beacon shard ID
-	node.serviceManager.RegisterService(service.NetworkInfo, networkinfo.MustNew(node.host, nodeconfig.NewGroupIDByShardID(0), nil, nil, ""))
+	networkInfo, err := node.newNetworkInfo(nodeconfig.NewGroupIDByShardID(0), chanPeer, "")
+	if err != nil {
+		return err
+	}
+	node.serviceManager.RegisterService(service.NetworkInfo, networkInfo)
+	return nil
 }
 
-func (node *Node) setupForExplorerNode() {
+func (node *Node) setupForExplorerNode() error {
 	nodeConfig, chanPeer := node.initNodeConfiguration()
 
 	// Register peer discovery service.
 	node.serviceManager.RegisterService(service.PeerDiscovery, discovery.New(node.host, nodeConfig, chanPeer, nil))
 	// Register networkinfo service.
-	node.serviceManager.RegisterService(service.NetworkInfo, networkinfo.MustNew(node.host, node.NodeConfig.GetShardGroupID(), chanPeer, nil, node.networkInfoDHTPath()))
+	networkInfo, err := node.newNetworkInfo(node.NodeConfig.GetShardGroupID(), chanPeer, node.networkInfoDHTPath())
+	if err != nil {
+		return err
+	}
+	node.serviceManager.RegisterService(service.NetworkInfo, networkInfo)
 	// Register explorer service.
 	node.serviceManager.RegisterService(service.SupportExplorer, explorer.New(&node.SelfPeer))
-	// Register explorer service.
+	return nil
 }
 
 // ServiceManagerSetup setups service store.
-func (node *Node) ServiceManagerSetup() {
+func (node *Node) ServiceManagerSetup() error {
 	node.serviceManager = &service.Manager{}
 	node.serviceMessageChan = make(map[service.Type]chan *msg_pb.Message)
+	var err error
 	switch node.NodeConfig.Role() {
 	case nodeconfig.Validator:
-		node.setupForValidator()
+		err = node.setupForValidator()
 	case nodeconfig.ClientNode:
-		node.setupForClientNode()
+		err = node.setupForClientNode()
 	case nodeconfig.ExplorerNode:
-		node.setupForExplorerNode()
+		err = node.setupForExplorerNode()
+	}
+	if err != nil {
+		return err
 	}
 	node.serviceManager.SetupServiceMessageChan(node.serviceMessageChan)
+	return nil
+}
+
+// newNetworkInfo creates the network info service, which connects to the
+// bootnodes with the node's BootnodeConnectOptions.
+func (node *Node) newNetworkInfo(
+	rendezvous nodeconfig.GroupID, chanPeer chan p2p.Peer, dataStorePath string,
+) (*networkinfo.Service, error) {
+	networkInfo, err := networkinfo.New(node.host, rendezvous, chanPeer, nil, dataStorePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot set up the network info service")
+	}
+	networkInfo.SetConnectOptions(node.BootnodeConnectOptions)
+	return networkInfo, nil
+}
+
+// NetworkInfoErr waits until the network info service is started and returns
+// the error, if any, of it joining the network through the bootnodes.
+func (node *Node) NetworkInfoErr() error {
+	if node.serviceManager == nil {
+		return errors.New("service manager is not set up yet")
+	}
+	networkInfo, ok := node.serviceManager.GetServices()[service.NetworkInfo].(*networkinfo.Service)
+	if !ok {
+		return nil
+	}
+	return networkInfo.InitErr()
 }
 
 // RunServices runs registered services.
@@ -0,0 +1,239 @@
+// Package chaos wraps a p2p host to inject network faults into the messages
+// it receives: latency, jitter, drops, reordering and partitions from given
+// peers. The faults can be changed at runtime, e.g. over HTTP, to test the
+// robustness of consensus without tc/netem.
+//
+// The faults apply to the incoming group messages only. A partition between
+// two nodes is symmetric if both of them run the wrapper and partition the
+// other one.
+package chaos
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
+	"github.com/harmony-one/harmony/p2p"
+	libp2p_peer "github.com/libp2p/go-libp2p-peer"
+	"github.com/pkg/errors"
+)
+
+// Faults are the faults injected into the incoming messages
+type Faults struct {
+	// Latency delays every message
+	Latency time.Duration
+	// Jitter adds a uniformly random delay up to itself to every message
+	Jitter time.Duration
+	// DropRate is the probability of dropping a message
+	DropRate float64
+	// ReorderRate is the probability of delivering a message after the next one
+	ReorderRate float64
+	// Partitioned are the peers whose messages are all dropped
+	Partitioned []libp2p_peer.ID
+}
+
+// Validate checks the faults are in range.
+func (f *Faults) Validate() error {
+	if f.Latency < 0 || f.Jitter < 0 {
+		return errors.New("negative latency or jitter")
+	}
+	if f.DropRate < 0 || f.DropRate > 1 || f.ReorderRate < 0 || f.ReorderRate > 1 {
+		return errors.New("drop and reorder rates must be between 0 and 1")
+	}
+	return nil
+}
+
+// Host is a p2p host injecting faults into the messages it receives
+type Host struct {
+	p2p.Host
+
+	mutex       sync.RWMutex
+	faults      Faults
+	partitioned map[libp2p_peer.ID]bool
+	rand        *rand.Rand // guarded by mutex as well
+}
+
+// New returns a host wrapping the given one, without any fault injected.
+func New(host p2p.Host) *Host {
+	return &Host{
+		Host:        host,
+		partitioned: make(map[libp2p_peer.ID]bool),
+		rand:        rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Faults returns the faults being injected.
+func (h *Host) Faults() Faults {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	faults := h.faults
+	faults.Partitioned = append([]libp2p_peer.ID(nil), h.faults.Partitioned...)
+	return faults
+}
+
+// SetFaults replaces the faults being injected; the zero Faults heal the
+// network.
+func (h *Host) SetFaults(faults Faults) error {
+	if err := faults.Validate(); err != nil {
+		return err
+	}
+	partitioned := make(map[libp2p_peer.ID]bool)
+	for _, peer := range faults.Partitioned {
+		partitioned[peer] = true
+	}
+	faults.Partitioned = append([]libp2p_peer.ID(nil), faults.Partitioned...)
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.faults = faults
+	h.partitioned = partitioned
+	return nil
+}
+
+// fate decides whether to drop or reorder a message from the sender, and
+// by how long to delay it.
+func (h *Host) fate(sender libp2p_peer.ID) (drop, reorder bool, delay time.Duration) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if h.partitioned[sender] || h.rand.Float64() < h.faults.DropRate {
+		return true, false, 0
+	}
+	delay = h.faults.Latency
+	if h.faults.Jitter > 0 {
+		delay += time.Duration(h.rand.Int63n(int64(h.faults.Jitter) + 1))
+	}
+	return false, h.rand.Float64() < h.faults.ReorderRate, delay
+}
+
+// minHold is the least time a message held for reordering waits for the next
+// one.
+const minHold = 100 * time.Millisecond
+
+// holdTime returns how long a message held for reordering waits for the next
+// one before it is delivered alone: the longest delay of the faults.
+func (h *Host) holdTime() time.Duration {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	if hold := h.faults.Latency + h.faults.Jitter; hold > minHold {
+		return hold
+	}
+	return minHold
+}
+
+// GroupReceiver returns a receiver of the group messages with the faults
+// injected.
+func (h *Host) GroupReceiver(group nodeconfig.GroupID) (p2p.GroupReceiver, error) {
+	receiver, err := h.Host.GroupReceiver(group)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &groupReceiver{
+		host:     h,
+		receiver: receiver,
+		cancel:   cancel,
+		messages: make(chan message, messageBufferSize),
+		closed:   make(chan struct{}),
+	}
+	go r.pump(ctx)
+	return r, nil
+}
+
+const messageBufferSize = 1024
+
+type message struct {
+	msg    []byte
+	sender libp2p_peer.ID
+	err    error
+}
+
+type groupReceiver struct {
+	host      *Host
+	receiver  p2p.GroupReceiver
+	cancel    context.CancelFunc
+	messages  chan message
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// pump receives the messages from the wrapped receiver and schedules their
+// delivery.  A message held for reordering is delivered after the next one,
+// or alone once its hold time passes without another message.
+func (r *groupReceiver) pump(ctx context.Context) {
+	var (
+		held    *message
+		release *time.Timer
+	)
+	for {
+		msg, sender, err := r.receiver.Receive(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			r.deliver(0, message{err: err})
+			continue
+		}
+		drop, reorder, delay := r.host.fate(sender)
+		if drop {
+			continue
+		}
+		if held != nil && !release.Stop() {
+			// already delivered alone
+			held = nil
+		}
+		current := message{msg: msg, sender: sender}
+		if reorder && held == nil {
+			held = &current
+			release = time.AfterFunc(delay+r.host.holdTime(), func() {
+				r.deliver(0, current)
+			})
+			continue
+		}
+		if held != nil {
+			r.deliver(delay, current, *held)
+			held = nil
+		} else {
+			r.deliver(delay, current)
+		}
+	}
+}
+
+// deliver queues the messages in order after the delay.
+func (r *groupReceiver) deliver(delay time.Duration, msgs ...message) {
+	push := func() {
+		for _, msg := range msgs {
+			select {
+			case r.messages <- msg:
+			case <-r.closed:
+				return
+			}
+		}
+	}
+	if delay <= 0 {
+		push()
+	} else {
+		time.AfterFunc(delay, push)
+	}
+}
+
+// Receive returns the next message that survived the faults.
+func (r *groupReceiver) Receive(ctx context.Context) ([]byte, libp2p_peer.ID, error) {
+	select {
+	case msg := <-r.messages:
+		return msg.msg, msg.sender, msg.err
+	case <-r.closed:
+		return nil, "", errors.New("receiver closed")
+	case <-ctx.Done():
+		return nil, "", ctx.Err()
+	}
+}
+
+// Close closes the receiver and the wrapped one.
+func (r *groupReceiver) Close() error {
+	r.closeOnce.Do(func() {
+		close(r.closed)
+		r.cancel()
+	})
+	return r.receiver.Close()
+}
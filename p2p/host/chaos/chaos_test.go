@@ -0,0 +1,154 @@
+package chaos
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
+	"github.com/harmony-one/harmony/p2p"
+	libp2p_peer "github.com/libp2p/go-libp2p-peer"
+)
+
+type testMessage struct {
+	msg    []byte
+	sender libp2p_peer.ID
+}
+
+// testHost delivers the messages written to its channel to its receiver
+type testHost struct {
+	p2p.Host
+	messages chan testMessage
+}
+
+func (h *testHost) GroupReceiver(nodeconfig.GroupID) (p2p.GroupReceiver, error) {
+	return &testReceiver{h.messages}, nil
+}
+
+type testReceiver struct {
+	messages chan testMessage
+}
+
+func (r *testReceiver) Receive(ctx context.Context) ([]byte, libp2p_peer.ID, error) {
+	select {
+	case m := <-r.messages:
+		return m.msg, m.sender, nil
+	case <-ctx.Done():
+		return nil, "", ctx.Err()
+	}
+}
+
+func (r *testReceiver) Close() error { return nil }
+
+func newTestReceiver(t *testing.T, faults Faults) (chan testMessage, p2p.GroupReceiver) {
+	messages := make(chan testMessage, 10)
+	host := New(&testHost{messages: messages})
+	if err := host.SetFaults(faults); err != nil {
+		t.Fatal(err)
+	}
+	receiver, err := host.GroupReceiver(nodeconfig.GroupIDBeacon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return messages, receiver
+}
+
+func receive(t *testing.T, receiver p2p.GroupReceiver, timeout time.Duration) (string, libp2p_peer.ID) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	msg, sender, err := receiver.Receive(ctx)
+	if err != nil {
+		return "", ""
+	}
+	return string(msg), sender
+}
+
+func TestPartitionAndDrop(t *testing.T) {
+	messages, receiver := newTestReceiver(t, Faults{Partitioned: []libp2p_peer.ID{"bad"}})
+	defer receiver.Close()
+	messages <- testMessage{[]byte("1"), "bad"}
+	messages <- testMessage{[]byte("2"), "good"}
+	if msg, sender := receive(t, receiver, time.Second); msg != "2" || sender != "good" {
+		t.Errorf("got %#v from %v", msg, sender)
+	}
+
+	messages, receiver = newTestReceiver(t, Faults{DropRate: 1})
+	defer receiver.Close()
+	messages <- testMessage{[]byte("1"), "good"}
+	if msg, _ := receive(t, receiver, 50*time.Millisecond); msg != "" {
+		t.Errorf("got %#v at drop rate 1", msg)
+	}
+}
+
+func TestReorderAndLatency(t *testing.T) {
+	messages, receiver := newTestReceiver(t, Faults{ReorderRate: 1})
+	defer receiver.Close()
+	for _, msg := range []string{"1", "2", "3", "4"} {
+		messages <- testMessage{[]byte(msg), "good"}
+	}
+	var got []string
+	for range []int{1, 2, 3, 4} {
+		msg, _ := receive(t, receiver, time.Second)
+		got = append(got, msg)
+	}
+	if strings.Join(got, "") != "2143" {
+		t.Errorf("got %v", got)
+	}
+
+	// a held message without a successor is delivered after its hold time
+	messages <- testMessage{[]byte("5"), "good"}
+	if msg, _ := receive(t, receiver, time.Second); msg != "5" {
+		t.Errorf("got %#v for a held message", msg)
+	}
+
+	messages, receiver = newTestReceiver(t, Faults{Latency: 100 * time.Millisecond})
+	defer receiver.Close()
+	start := time.Now()
+	messages <- testMessage{[]byte("1"), "good"}
+	if msg, _ := receive(t, receiver, time.Second); msg != "1" {
+		t.Errorf("got %#v", msg)
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("delivered after %v", elapsed)
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	host := New(&testHost{})
+	server := httptest.NewServer(host)
+	defer server.Close()
+
+	peer := "QmVdSe4RdeC3xPemFuRG9Mhzo28EXbiBZh1tpjsuMbHqh1"
+	body := `{"latency": "100ms", "drop_rate": 0.5, "partitioned": ["` + peer + `"]}`
+	resp, err := http.Post(server.URL, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("got status %v", resp.Status)
+	}
+	faults := host.Faults()
+	if faults.Latency != 100*time.Millisecond || faults.DropRate != 0.5 ||
+		len(faults.Partitioned) != 1 || faults.Partitioned[0].Pretty() != peer {
+		t.Errorf("got faults %+v", faults)
+	}
+
+	for _, body := range []string{`{"drop_rate": 2}`, `{"latency": "soon"}`, `{"partitioned": ["x"]}`} {
+		resp, err := http.Post(server.URL, "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("%s: got status %v", body, resp.Status)
+		}
+	}
+
+	if err := host.SetFaults(Faults{Jitter: -1}); err == nil {
+		t.Error("negative jitter accepted")
+	}
+}
@@ -0,0 +1,89 @@
+package chaos
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	libp2p_peer "github.com/libp2p/go-libp2p-peer"
+	"github.com/pkg/errors"
+)
+
+// jsonFaults is the JSON form of Faults, with Go durations such as "150ms"
+type jsonFaults struct {
+	Latency     string   `json:"latency"`
+	Jitter      string   `json:"jitter"`
+	DropRate    float64  `json:"drop_rate"`
+	ReorderRate float64  `json:"reorder_rate"`
+	Partitioned []string `json:"partitioned"`
+}
+
+func (f *Faults) toJSON() jsonFaults {
+	partitioned := make([]string, len(f.Partitioned))
+	for i, peer := range f.Partitioned {
+		partitioned[i] = peer.Pretty()
+	}
+	return jsonFaults{
+		Latency:     f.Latency.String(),
+		Jitter:      f.Jitter.String(),
+		DropRate:    f.DropRate,
+		ReorderRate: f.ReorderRate,
+		Partitioned: partitioned,
+	}
+}
+
+func (jf *jsonFaults) toFaults() (Faults, error) {
+	var faults Faults
+	var err error
+	if jf.Latency != "" {
+		if faults.Latency, err = time.ParseDuration(jf.Latency); err != nil {
+			return faults, errors.Wrap(err, "invalid latency")
+		}
+	}
+	if jf.Jitter != "" {
+		if faults.Jitter, err = time.ParseDuration(jf.Jitter); err != nil {
+			return faults, errors.Wrap(err, "invalid jitter")
+		}
+	}
+	faults.DropRate = jf.DropRate
+	faults.ReorderRate = jf.ReorderRate
+	for _, str := range jf.Partitioned {
+		peer, err := libp2p_peer.IDB58Decode(str)
+		if err != nil {
+			return faults, errors.Wrapf(err, "invalid peer ID %#v", str)
+		}
+		faults.Partitioned = append(faults.Partitioned, peer)
+	}
+	return faults, faults.Validate()
+}
+
+// ServeHTTP returns the faults being injected on GET and replaces them on
+// POST or PUT, in JSON, e.g. {"latency": "100ms", "jitter": "50ms",
+// "drop_rate": 0.1, "reorder_rate": 0.05, "partitioned": ["QmVdS..."]}.
+// Omitted fields are zero; posting {} heals the network.
+func (h *Host) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost, http.MethodPut:
+		var jf jsonFaults
+		if err := json.NewDecoder(r.Body).Decode(&jf); err != nil {
+			http.Error(w, "invalid JSON: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		faults, err := jf.toFaults()
+		if err == nil {
+			err = h.SetFaults(faults)
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, POST, PUT")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	faults := h.Faults()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(faults.toJSON())
+}
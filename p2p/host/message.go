@@ -2,13 +2,48 @@ package host
 
 import (
 	"encoding/binary"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// p2pMessageVersion is the first byte of every p2p message, once meant as
+	// a message type and always 17 (0x11). It identifies the layout of the
+	// message: receivers drop messages of another version instead of
+	// misinterpreting them, so that a future layout can be rolled out.
+	p2pMessageVersion = 17
+	// P2pMessagePrefixSize is the size of the header preceding the content
+	P2pMessagePrefixSize = 5
 )
 
-// ConstructP2pMessage constructs the p2p message as [messageType, contentSize, content]
+// Errors returned by ParseP2pMessage
+var (
+	ErrP2pMessageTooShort       = errors.New("p2p message shorter than its header")
+	ErrUnknownP2pMessageVersion = errors.New("unknown p2p message version")
+	ErrP2pMessageSizeMismatch   = errors.New("p2p message size differs from its header")
+)
+
+// ConstructP2pMessage constructs the p2p message as [version, contentSize, content]
 func ConstructP2pMessage(msgType byte, content []byte) []byte {
-	message := make([]byte, 5+len(content))
-	message[0] = 17 // messageType 0x11
-	binary.BigEndian.PutUint32(message[1:5], uint32(len(content)))
-	copy(message[5:], content)
+	message := make([]byte, P2pMessagePrefixSize+len(content))
+	message[0] = p2pMessageVersion
+	binary.BigEndian.PutUint32(message[1:P2pMessagePrefixSize], uint32(len(content)))
+	copy(message[P2pMessagePrefixSize:], content)
 	return message
 }
+
+// ParseP2pMessage checks the header of a message constructed with
+// ConstructP2pMessage and returns its content.
+func ParseP2pMessage(message []byte) ([]byte, error) {
+	if len(message) < P2pMessagePrefixSize {
+		return nil, ErrP2pMessageTooShort
+	}
+	if version := message[0]; version != p2pMessageVersion {
+		return nil, errors.Wrapf(ErrUnknownP2pMessageVersion, "version %d", version)
+	}
+	content := message[P2pMessagePrefixSize:]
+	if size := binary.BigEndian.Uint32(message[1:P2pMessagePrefixSize]); int64(size) != int64(len(content)) {
+		return nil, errors.Wrapf(ErrP2pMessageSizeMismatch, "header %d, actual %d", size, len(content))
+	}
+	return content, nil
+}
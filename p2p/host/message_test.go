@@ -0,0 +1,41 @@
+package host
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestParseP2pMessage(t *testing.T) {
+	content := []byte("hello")
+	message := ConstructP2pMessage(byte(0), content)
+	parsed, err := ParseP2pMessage(message)
+	if err != nil {
+		t.Fatalf("cannot parse message: %v", err)
+	}
+	if !bytes.Equal(parsed, content) {
+		t.Errorf("got content %x, expected %x", parsed, content)
+	}
+
+	if _, err := ParseP2pMessage(ConstructP2pMessage(byte(0), nil)); err != nil {
+		t.Errorf("cannot parse empty message: %v", err)
+	}
+
+	newVersion := append([]byte{}, message...)
+	newVersion[0]++
+	tests := []struct {
+		message []byte
+		err     error
+	}{
+		{message[:4], ErrP2pMessageTooShort},
+		{newVersion, ErrUnknownP2pMessageVersion},
+		{message[:len(message)-1], ErrP2pMessageSizeMismatch},
+		{append(message, 0), ErrP2pMessageSizeMismatch},
+	}
+	for i, test := range tests {
+		if _, err := ParseP2pMessage(test.message); errors.Cause(err) != test.err {
+			t.Errorf("message %d: got error %v, expected %v", i, err, test.err)
+		}
+	}
+}
@@ -35,6 +35,11 @@ func (al *AddrList) Set(value string) error {
 	return nil
 }
 
+// Reset empties the AddrList, so that it can be Set again
+func (al *AddrList) Reset() {
+	*al = nil
+}
+
 // StringsToAddrs convert a list of strings to a list of multiaddresses
 func StringsToAddrs(addrStrings []string) (maddrs []ma.Multiaddr, err error) {
 	for _, addrString := range addrStrings {
@@ -6,6 +6,9 @@ declare -A SRC
 SRC[harmony]=cmd/harmony/main.go
 # SRC[txgen]=cmd/client/txgen/main.go
 SRC[bootnode]=cmd/bootnode/main.go
+SRC[faucet]="cmd/faucet/main.go cmd/faucet/faucet.go"
+SRC[bench]="cmd/bench/main.go cmd/bench/stats.go"
+SRC[localnet]="cmd/localnet/main.go cmd/localnet/config.go"
 SRC[wallet]="cmd/client/wallet/main.go cmd/client/wallet/generated_wallet.ini.go"
 # SRC[wallet_stress_test]="cmd/client/wallet_stress_test/main.go cmd/client/wallet_stress_test/generated_wallet.ini.go"
 
@@ -72,7 +75,7 @@ ACTION:
    pubwallet   upload wallet to public bucket (bucket: $PUBBUCKET)
    release     upload binaries to release bucket
 
-   harmony|txgen|bootnode|wallet
+   harmony|txgen|bootnode|wallet|faucet|bench|localnet
                only build the specified binary
 
 EXAMPLES:
@@ -309,6 +312,6 @@ case "$ACTION" in
    "upload") upload ;;
    "release") release ;;
    "pubwallet") upload_wallet ;;
-   "harmony"|"wallet"|"txgen"|"bootnode") build_only $ACTION ;;
+   "harmony"|"wallet"|"txgen"|"bootnode"|"faucet"|"bench"|"localnet") build_only $ACTION ;;
    *) usage ;;
 esac